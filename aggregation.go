@@ -65,10 +65,10 @@ type AggregationOption struct {
 // Aggregation Get aggregate bars for a ticker over a given date range in custom time window sizes
 func (c Client) Aggregation(ctx context.Context, ticker string, multiplier int, timespan Timespan, from, to time.Time, opt *AggregationOption) (Aggregation, error) {
 	a := Aggregation{}
-	endpoint, err := c.endpointWithOpts(fmt.Sprintf("/aggs/ticker/%s/range/%d/%s/%s/%s", ticker, multiplier, timespan, ttoa(from), ttoa(to)), opt)
+	endpoint, err := c.endpointWithOpts(fmt.Sprintf("/v2/aggs/ticker/%s/range/%d/%s/%s/%s", ticker, multiplier, timespan, ttoa(from), ttoa(to)), opt)
 	if err != nil {
 		return a, err
 	}
-	err = c.GetJSON(ctx, endpoint, &a)
+	err = c.fetchJSON(ctx, "Aggregation", endpoint, &a)
 	return a, err
 }
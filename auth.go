@@ -0,0 +1,70 @@
+package polygon
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// AuthMode defines how the API key is sent to polygon
+type AuthMode int
+
+const (
+	// AuthQueryParam sends the API key in the apiKey query parameter (default)
+	AuthQueryParam AuthMode = iota
+	// AuthHeader sends the API key in an `Authorization: Bearer <token>` header,
+	// which keeps it out of URLs, proxy logs and traces
+	AuthHeader
+)
+
+const redacted = "REDACTED"
+
+// WithAuthMode sets how the API key is sent for a new Polygon Client
+func WithAuthMode(mode AuthMode) ClientOption {
+	return func(client *Client) {
+		client.authMode = mode
+	}
+}
+
+// authQueryParams returns the query parameters carrying the API key, nil when it is sent in a header
+func (c *Client) authQueryParams() map[string]string {
+	if c.authMode != AuthQueryParam {
+		return nil
+	}
+	return map[string]string{"apiKey": c.token}
+}
+
+// RedactURL replaces the value of the apiKey query parameter of the given URL
+func RedactURL(address string) string {
+	u, err := url.Parse(address)
+	if err != nil || !u.Query().Has("apiKey") {
+		return address
+	}
+
+	q := u.Query()
+	q.Set("apiKey", redacted)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// redactURL removes the API key from a URL before it appears in errors or logs
+func (c *Client) redactURL(address string) string {
+	return c.redact(RedactURL(address))
+}
+
+// redact removes every occurrence of the API key from s
+func (c *Client) redact(s string) string {
+	if c.token == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, c.token, redacted)
+}
+
+// redactError removes the API key from the URL carried by a transport error
+func (c *Client) redactError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = c.redactURL(urlErr.URL)
+	}
+	return err
+}
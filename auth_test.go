@@ -0,0 +1,57 @@
+package polygon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthHeaderMode(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+		assert.False(t, r.URL.Query().Has("apiKey"))
+
+		next := ""
+		if !r.URL.Query().Has("cursor") {
+			next = srv.URL + "/v3/reference/splits?cursor=1"
+		}
+		fmt.Fprintf(w, `{"status":"OK","next_url":%q,"results":[{"ticker":"AAPL"}]}`, next)
+	}))
+	defer srv.Close()
+
+	client := NewClient("test-token", WithBaseURL(srv.URL+"/v2"), WithAuthMode(AuthHeader))
+	count := 0
+	for _, err := range client.StockSplitsIter(context.Background(), nil) {
+		assert.NoError(t, err)
+		count++
+	}
+	assert.Equal(t, 2, count)
+}
+
+func TestAuthRedactedFromErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, `{"status":"ERROR","error":"unknown API key %s"}`, r.URL.Query().Get("apiKey"))
+	}))
+	client := NewClient("secret-token", WithBaseURL(srv.URL))
+
+	_, err := client.GetBytes(context.Background(), "/value")
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-token")
+
+	srv.Close()
+	_, err = client.GetBytes(context.Background(), "/value")
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-token")
+	assert.Contains(t, err.Error(), "apiKey=REDACTED")
+}
+
+func TestRedactURL(t *testing.T) {
+	assert.Equal(t, "https://api.polygon.io/v2/x?apiKey=REDACTED&limit=1", RedactURL("https://api.polygon.io/v2/x?limit=1&apiKey=abc"))
+	assert.Equal(t, "https://api.polygon.io/v2/x?limit=1", RedactURL("https://api.polygon.io/v2/x?limit=1"))
+}
@@ -0,0 +1,121 @@
+package polygon
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchResult result of a single key of a batch
+type BatchResult[T any] struct {
+	Key   string
+	Value T
+	Err   error
+}
+
+// BatchProgress progress of a batch, reported after every key
+type BatchProgress struct {
+	Done  int
+	Total int
+	Key   string
+	Err   error
+}
+
+// BatchOption applies an option to a batch.
+type BatchOption func(*batchConfig)
+
+type batchConfig struct {
+	progress func(BatchProgress)
+}
+
+// WithProgress reports the progress of a batch, fn is never called concurrently
+func WithProgress(fn func(BatchProgress)) BatchOption {
+	return func(cfg *batchConfig) {
+		cfg.progress = fn
+	}
+}
+
+// Batch runs fn for every key with at most concurrency calls in flight.
+// A failing key does not stop the batch: results are returned in the order of keys, each with its own error.
+// Requests still go through the client rate limiter, so concurrency only bounds the parallelism.
+func Batch[T any](ctx context.Context, keys []string, concurrency int, fn func(ctx context.Context, key string) (T, error), opts ...BatchOption) []BatchResult[T] {
+	cfg := batchConfig{}
+	for _, applyOption := range opts {
+		applyOption(&cfg)
+	}
+
+	results := make([]BatchResult[T], len(keys))
+	sem := make(chan struct{}, max(concurrency, 1))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	done := 0
+
+	for i, key := range keys {
+		results[i].Key = key
+
+		acquired := false
+		select {
+		case sem <- struct{}{}:
+			acquired = true
+		case <-ctx.Done():
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if acquired {
+				defer func() { <-sem }()
+			}
+
+			if err := ctx.Err(); err != nil {
+				results[i].Err = err
+			} else {
+				results[i].Value, results[i].Err = fn(ctx, key)
+			}
+
+			if cfg.progress != nil {
+				mu.Lock()
+				done++
+				cfg.progress(BatchProgress{Done: done, Total: len(keys), Key: key, Err: results[i].Err})
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
+
+// BatchTickerDetail fetches the ticker detail of every ticker
+func (c Client) BatchTickerDetail(ctx context.Context, tickers []string, concurrency int, opts ...BatchOption) []BatchResult[TickerDetail] {
+	return Batch(ctx, tickers, concurrency, func(ctx context.Context, ticker string) (TickerDetail, error) {
+		return c.TickerDetail(ctx, ticker, nil)
+	}, opts...)
+}
+
+// BatchAggregation fetches aggregate bars of every ticker over the same date range
+func (c Client) BatchAggregation(ctx context.Context, tickers []string, multiplier int, timespan Timespan, from, to time.Time, opt *AggregationOption, concurrency int, opts ...BatchOption) []BatchResult[Aggregation] {
+	return Batch(ctx, tickers, concurrency, func(ctx context.Context, ticker string) (Aggregation, error) {
+		return c.Aggregation(ctx, ticker, multiplier, timespan, from, to, opt)
+	}, opts...)
+}
+
+// BatchPrevClose fetches the previous day's OHLC of every ticker
+func (c Client) BatchPrevClose(ctx context.Context, tickers []string, opt *PrevCloseOption, concurrency int, opts ...BatchOption) []BatchResult[PrevClose] {
+	return Batch(ctx, tickers, concurrency, func(ctx context.Context, ticker string) (PrevClose, error) {
+		return c.PrevClose(ctx, ticker, opt)
+	}, opts...)
+}
+
+// BatchLastestDiviend fetches the latest dividend of every ticker
+func (c Client) BatchLastestDiviend(ctx context.Context, tickers []string, opt *DividendOption, concurrency int, opts ...BatchOption) []BatchResult[DividendResult] {
+	return Batch(ctx, tickers, concurrency, func(ctx context.Context, ticker string) (DividendResult, error) {
+		// every call gets its own copy, as LastestDiviend mutates the option
+		var o *DividendOption
+		if opt != nil {
+			copied := *opt
+			o = &copied
+		}
+		return c.LastestDiviend(ctx, ticker, o)
+	}, opts...)
+}
@@ -0,0 +1,92 @@
+package polygon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchBoundedParallelism(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	keys := []string{"A", "B", "C", "D", "E", "F"}
+	var progress []BatchProgress
+
+	results := Batch(context.Background(), keys, 2, func(ctx context.Context, key string) (string, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		if key == "C" {
+			return "", errors.New("boom")
+		}
+		return strings.ToLower(key), nil
+	}, WithProgress(func(p BatchProgress) {
+		progress = append(progress, p)
+	}))
+
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	assert.Len(t, results, len(keys))
+	for i, r := range results {
+		assert.Equal(t, keys[i], r.Key)
+		if r.Key == "C" {
+			assert.Error(t, r.Err)
+			continue
+		}
+		assert.NoError(t, r.Err)
+		assert.Equal(t, strings.ToLower(r.Key), r.Value)
+	}
+	assert.Len(t, progress, len(keys))
+	assert.Equal(t, len(keys), progress[len(progress)-1].Done)
+}
+
+func TestBatchTickerDetail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/MISSING") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"status":"OK","results":{"ticker":"` + r.URL.Path[len("/v3/reference/tickers/"):] + `"}}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("test-token", WithBaseURL(srv.URL))
+	results := client.BatchTickerDetail(context.Background(), []string{"AAPL", "MISSING", "NVDA"}, 2)
+
+	assert.Equal(t, "AAPL", results[0].Value.Results.Ticker)
+	assert.True(t, IsNotFound(results[1].Err))
+	assert.Equal(t, "NVDA", results[2].Value.Results.Ticker)
+}
+
+func TestBatchLastestDiviendOption(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "CD", q.Get("dividend_type"))
+		assert.Equal(t, "1", q.Get("limit"))
+		w.Write([]byte(`{"status":"OK","results":[{"ticker":"` + q.Get("ticker") + `"}]}`))
+	}))
+	defer srv.Close()
+
+	opt := &DividendOption{DividendType: "CD"}
+	client := NewClient("test-token", WithBaseURL(srv.URL))
+	results := client.BatchLastestDiviend(context.Background(), []string{"AAPL", "MSFT", "NVDA"}, opt, 3)
+
+	for i, ticker := range []string{"AAPL", "MSFT", "NVDA"} {
+		assert.NoError(t, results[i].Err)
+		assert.Equal(t, ticker, results[i].Value.Ticker)
+	}
+	// the caller's option is left untouched
+	assert.Equal(t, &DividendOption{DividendType: "CD"}, opt)
+}
@@ -0,0 +1,331 @@
+package polygon
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Cache stores raw responses. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the entry stored for key
+	Get(key string) (CacheEntry, bool)
+	// Set stores an entry for key
+	Set(key string, entry CacheEntry)
+}
+
+// CacheEntry a cached response
+type CacheEntry struct {
+	Data []byte `json:"data"`
+	// ExpiresAt is the time after which the entry is stale
+	ExpiresAt time.Time `json:"expires_at"`
+	// StaleUntil is the time after which the entry can be discarded, it is at least ExpiresAt
+	StaleUntil time.Time `json:"stale_until"`
+}
+
+// EndpointFamily groups endpoints sharing the same freshness requirements
+type EndpointFamily string
+
+const (
+	// FamilyReference TickerDetail, TickerEvent, StockSplits, Dividend, Financials and IncomeStatements
+	FamilyReference EndpointFamily = "reference"
+	// FamilyAggregates Aggregation, PrevClose, StockOpenClose and CryptoOpenClose
+	FamilyAggregates EndpointFamily = "aggregates"
+	// FamilyIndicators SimpleMovingAverage, ExponentialMovingAverage and RelativeStrengthIndex
+	FamilyIndicators EndpointFamily = "indicators"
+	// FamilyNews News
+	FamilyNews EndpointFamily = "news"
+	// FamilySnapshot MarketStatus and Summary
+	FamilySnapshot EndpointFamily = "snapshot"
+	// FamilyOther requests made through GetJSON and friends
+	FamilyOther EndpointFamily = ""
+)
+
+var endpointFamilies = map[string]EndpointFamily{
+	"TickerDetail":             FamilyReference,
+	"TickerEvent":              FamilyReference,
+	"StockSplits":              FamilyReference,
+	"Dividend":                 FamilyReference,
+	"Financials":               FamilyReference,
+	"IncomeStatements":         FamilyReference,
+	"Aggregation":              FamilyAggregates,
+	"PrevClose":                FamilyAggregates,
+	"StockOpenClose":           FamilyAggregates,
+	"CryptoOpenClose":          FamilyAggregates,
+	"SimpleMovingAverage":      FamilyIndicators,
+	"ExponentialMovingAverage": FamilyIndicators,
+	"RelativeStrengthIndex":    FamilyIndicators,
+	"News":                     FamilyNews,
+	"MarketStatus":             FamilySnapshot,
+	"Summary":                  FamilySnapshot,
+}
+
+// EndpointFamilyOf returns the family of a logical endpoint name
+func EndpointFamilyOf(endpoint string) EndpointFamily {
+	return endpointFamilies[endpoint]
+}
+
+// CachePolicy defines how long responses are cached
+type CachePolicy struct {
+	// TTL per endpoint family, families without a positive TTL are not cached
+	TTL map[EndpointFamily]time.Duration
+	// StaleWhileRevalidate serves an expired entry for up to this long while it is refreshed in the background
+	StaleWhileRevalidate time.Duration
+}
+
+// DefaultCachePolicy caches reference data, which changes at most daily, for 12 hours
+func DefaultCachePolicy() CachePolicy {
+	return CachePolicy{
+		TTL: map[EndpointFamily]time.Duration{
+			FamilyReference: 12 * time.Hour,
+		},
+	}
+}
+
+// CacheStats cache counters
+type CacheStats struct {
+	Hits      uint64
+	StaleHits uint64
+	Misses    uint64
+}
+
+// responseCache is shared by all copies of a client
+type responseCache struct {
+	backend    Cache
+	policy     CachePolicy
+	hits       atomic.Uint64
+	staleHits  atomic.Uint64
+	misses     atomic.Uint64
+	refreshing sync.Map
+}
+
+// WithCache caches responses of a new Polygon Client according to the given policy
+func WithCache(cache Cache, policy CachePolicy) ClientOption {
+	return func(client *Client) {
+		client.cache = &responseCache{backend: cache, policy: policy}
+	}
+}
+
+// CacheStats returns the cache counters, zero if the client has no cache
+func (c *Client) CacheStats() CacheStats {
+	if c.cache == nil {
+		return CacheStats{}
+	}
+
+	return CacheStats{
+		Hits:      c.cache.hits.Load(),
+		StaleHits: c.cache.staleHits.Load(),
+		Misses:    c.cache.misses.Load(),
+	}
+}
+
+// cachedBytes gets the data of the given URL through the cache
+func (c *Client) cachedBytes(ctx context.Context, u *url.URL) ([]byte, error) {
+	if c.cache == nil {
+		return c.loadBytes(ctx, u)
+	}
+
+	ttl := c.cache.policy.TTL[EndpointFamilyOf(EndpointName(ctx))]
+	if ttl <= 0 {
+		return c.loadBytes(ctx, u)
+	}
+
+	key := cacheKey(u)
+	now := time.Now()
+	if entry, ok := c.cache.backend.Get(key); ok {
+		if now.Before(entry.ExpiresAt) {
+			c.cache.hits.Add(1)
+			return entry.Data, nil
+		}
+
+		if now.Before(entry.StaleUntil) {
+			c.cache.staleHits.Add(1)
+			c.revalidate(ctx, key, u, ttl)
+			return entry.Data, nil
+		}
+	}
+
+	c.cache.misses.Add(1)
+	return c.fetchAndStore(ctx, key, u, ttl)
+}
+
+func (c *Client) fetchAndStore(ctx context.Context, key string, u *url.URL, ttl time.Duration) ([]byte, error) {
+	data, err := c.loadBytes(ctx, u)
+	if err != nil {
+		return data, err
+	}
+
+	now := time.Now()
+	c.cache.backend.Set(key, CacheEntry{
+		Data:       data,
+		ExpiresAt:  now.Add(ttl),
+		StaleUntil: now.Add(ttl + c.cache.policy.StaleWhileRevalidate),
+	})
+	return data, nil
+}
+
+// revalidate refreshes an entry in the background, at most once at a time per key
+func (c *Client) revalidate(ctx context.Context, key string, u *url.URL, ttl time.Duration) {
+	if _, loaded := c.cache.refreshing.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer c.cache.refreshing.Delete(key)
+		c.fetchAndStore(ctx, key, u, ttl)
+	}()
+}
+
+// cacheKey normalizes the URL and leaves out the API key
+func cacheKey(u *url.URL) string {
+	k := *u
+	q := k.Query()
+	q.Del("apiKey")
+	k.RawQuery = q.Encode()
+	k.Fragment = ""
+	return k.String()
+}
+
+//////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////// LRU cache /////////////////////////////////////
+//////////////////////////////////////////////////////////////////////////////////////
+
+// LRUCache in-memory cache evicting the least recently used entries
+type LRUCache struct {
+	mu       sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewLRUCache creates an in-memory cache holding up to capacity entries
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{
+		capacity: max(capacity, 1),
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get implements Cache
+func (l *LRUCache) Get(key string) (CacheEntry, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	el, ok := l.items[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+
+	item := el.Value.(*lruItem)
+	if time.Now().After(item.entry.StaleUntil) {
+		l.order.Remove(el)
+		delete(l.items, key)
+		return CacheEntry{}, false
+	}
+
+	l.order.MoveToFront(el)
+	return item.entry, true
+}
+
+// Set implements Cache
+func (l *LRUCache) Set(key string, entry CacheEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if el, ok := l.items[key]; ok {
+		el.Value.(*lruItem).entry = entry
+		l.order.MoveToFront(el)
+		return
+	}
+
+	l.items[key] = l.order.PushFront(&lruItem{key: key, entry: entry})
+	for l.order.Len() > l.capacity {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.items, oldest.Value.(*lruItem).key)
+	}
+}
+
+// Len returns the number of entries
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+//////////////////////////////////////////////////////////////////////////////////////
+///////////////////////////////////// disk cache /////////////////////////////////////
+//////////////////////////////////////////////////////////////////////////////////////
+
+// DiskCache on-disk cache storing one JSON file per entry
+type DiskCache struct {
+	dir string
+}
+
+// NewDiskCache creates an on-disk cache in dir, the directory is created if needed
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// Get implements Cache, unreadable entries are treated as missing
+func (d *DiskCache) Get(key string) (CacheEntry, bool) {
+	path := d.path(key)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return CacheEntry{}, false
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(b, &entry); err != nil || time.Now().After(entry.StaleUntil) {
+		os.Remove(path)
+		return CacheEntry{}, false
+	}
+	return entry, true
+}
+
+// Set implements Cache, write failures are ignored
+func (d *DiskCache) Set(key string, entry CacheEntry) {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	// write then rename so readers never see a partial file
+	tmp, err := os.CreateTemp(d.dir, "*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return
+	}
+	if err := tmp.Close(); err != nil {
+		return
+	}
+	os.Rename(tmp.Name(), d.path(key))
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,103 @@
+package polygon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCountingServer(t *testing.T, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"status":"OK","results":{"ticker":"AAPL"}}`))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCacheHitMiss(t *testing.T) {
+	var calls atomic.Int32
+	srv := newCountingServer(t, &calls)
+	cache := NewLRUCache(10)
+	client := NewClient("token-a", WithBaseURL(srv.URL), WithCache(cache, DefaultCachePolicy()))
+
+	ctx := context.Background()
+	for range 3 {
+		d, err := client.TickerDetail(ctx, "AAPL", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "AAPL", d.Results.Ticker)
+	}
+
+	// the key leaves out the API key, so another client sharing the backend hits too
+	other := NewClient("token-b", WithBaseURL(srv.URL), WithCache(cache, DefaultCachePolicy()))
+	_, err := other.TickerDetail(ctx, "AAPL", nil)
+	assert.NoError(t, err)
+
+	// aggregates are not cached by the default policy
+	client.PrevClose(ctx, "AAPL", nil)
+
+	assert.Equal(t, int32(2), calls.Load())
+	assert.Equal(t, CacheStats{Hits: 2, Misses: 1}, client.CacheStats())
+}
+
+func TestCacheStaleWhileRevalidate(t *testing.T) {
+	var calls atomic.Int32
+	srv := newCountingServer(t, &calls)
+	client := NewClient("test-token", WithBaseURL(srv.URL), WithCache(NewLRUCache(10), CachePolicy{
+		TTL:                  map[EndpointFamily]time.Duration{FamilyReference: time.Millisecond},
+		StaleWhileRevalidate: time.Hour,
+	}))
+
+	ctx := context.Background()
+	_, err := client.TickerEvent(ctx, "AAPL", nil)
+	assert.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = client.TickerEvent(ctx, "AAPL", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), client.CacheStats().StaleHits)
+	assert.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, time.Millisecond)
+}
+
+func TestLRUCacheEviction(t *testing.T) {
+	cache := NewLRUCache(2)
+	entry := CacheEntry{Data: []byte("x"), ExpiresAt: time.Now().Add(time.Hour), StaleUntil: time.Now().Add(time.Hour)}
+	cache.Set("a", entry)
+	cache.Set("b", entry)
+	cache.Get("a")
+	cache.Set("c", entry)
+
+	_, ok := cache.Get("b")
+	assert.False(t, ok)
+	_, ok = cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestDiskCache(t *testing.T) {
+	cache, err := NewDiskCache(t.TempDir())
+	assert.NoError(t, err)
+
+	entry := CacheEntry{Data: []byte(`{"a":1}`), ExpiresAt: time.Now().Add(time.Hour), StaleUntil: time.Now().Add(time.Hour)}
+	cache.Set("key", entry)
+
+	got, ok := cache.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, entry.Data, got.Data)
+
+	cache.Set("expired", CacheEntry{Data: []byte("x")})
+	_, ok = cache.Get("expired")
+	assert.False(t, ok)
+}
+
+func TestCacheKey(t *testing.T) {
+	u, _ := url.Parse("https://api.polygon.io/v3/reference/tickers/AAPL?date=2024-01-01&apiKey=secret")
+	assert.Equal(t, "https://api.polygon.io/v3/reference/tickers/AAPL?date=2024-01-01", cacheKey(u))
+}
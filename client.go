@@ -16,56 +16,61 @@ import (
 	"github.com/google/go-querystring/query"
 )
 
-const apiURL = "https://api.polygon.io/v2"       // use v2 as default
+const apiURL = "https://api.polygon.io"          // endpoints declare their own versioned path
+const apiVersion = "/v2"                         // version used by GetJSON and GetBytes by default
 const websocketURL = "wss://business.polygon.io" // use business as default
 
 // Client models a client to consume the Polygon Cloud API.
 type Client struct {
-	baseURL          string
+	baseURL          string // host-only, e.g. https://api.polygon.io
+	apiVersion       string // path prefix of relative endpoints passed to GetJSON and GetBytes
 	websocketBaseURL string
+	feed             Feed
 	token            string
 	httpClient       *http.Client
+	retryPolicy      *RetryPolicy
+	rateLimiter      *RateLimiter
+	authMode         AuthMode
+	middlewares      []Middleware
+	cache            *responseCache
+	coalescer        *coalescer
+
+	websocketStatusTimeout       time.Duration
+	websocketSubscriptionHandler func(SubscriptionAck)
+	websocketFrameSize           int
 }
 
-// polygon api versions are not unified, sometimes we have to switch to v1
+// UseV1Endpoints switches the relative endpoints of GetJSON and GetBytes to v1.
+// Endpoint methods declare their own versioned path and do not depend on it.
 func (c Client) UseV1Endpoints() Client {
-	c.baseURL = strings.Replace(c.baseURL, "v2", "v1", 1)
+	c.apiVersion = "/v1"
 	return c
 }
 
-// polygon api versions are not unified, sometimes we have to switch to v3
+// UseV3Endpoints switches the relative endpoints of GetJSON and GetBytes to v3.
+// Endpoint methods declare their own versioned path and do not depend on it.
 func (c Client) UseV3Endpoints() Client {
-	c.baseURL = strings.Replace(c.baseURL, "v2", "v3", 1)
+	c.apiVersion = "/v3"
 	return c
 }
 
-// polygon api versions are not unified, sometimes we have to switch to vX
+// UseVXEndpoints switches the relative endpoints of GetJSON and GetBytes to vX.
+// Endpoint methods declare their own versioned path and do not depend on it.
 func (c Client) UseVXEndpoints() Client {
-	c.baseURL = strings.Replace(c.baseURL, "v2", "vX", 1)
+	c.apiVersion = "/vX"
 	return c
 }
 
-// UseFinancialsV1Endpoints switches to financials v1 as polygon api versions are not unified.
+// UseFinancialsV1Endpoints switches the relative endpoints of GetJSON and GetBytes to financials v1.
+// Endpoint methods declare their own versioned path and do not depend on it.
 func (c Client) UseFinancialsV1Endpoints() Client {
-	c.baseURL = strings.Replace(c.baseURL, "v2", "stocks/financials/v1", 1)
+	c.apiVersion = "/stocks/financials/v1"
 	return c
 }
 
-// Error represents an Polygon API error
-type Error struct {
-	Status       string `json:"status"`
-	ErrorMessage string `json:"error"`
-	RequestID    string `json:"request_id"`
-}
-
 // ClientOption applies an option to the client.
 type ClientOption func(*Client)
 
-// Error implements the error interface
-func (e Error) Error() string {
-	return fmt.Sprintf("%s: %s (request id: %s)", e.Status, e.ErrorMessage, e.RequestID)
-}
-
 // NewClient creates a client with the given authorization token.
 func NewClient(token string, options ...ClientOption) *Client {
 	client := &Client{
@@ -84,8 +89,21 @@ func NewClient(token string, options ...ClientOption) *Client {
 	}
 
 	// set default values
+	if client.apiVersion == "" {
+		client.apiVersion = apiVersion
+	}
+
+	// set default values
+	if client.feed == "" {
+		client.feed = FeedBusiness
+	}
+
+	// set default values, an explicit websocket base URL wins over the feed host
 	if client.websocketBaseURL == "" {
 		client.websocketBaseURL = websocketURL
+		if u, ok := feedURLs[client.feed]; ok {
+			client.websocketBaseURL = u
+		}
 	}
 
 	return client
@@ -114,10 +132,12 @@ func WithSecureHTTPClient() ClientOption {
 	}
 }
 
-// WithBaseURL sets the baseURL for a new Polygon Client
+// WithBaseURL sets the baseURL for a new Polygon Client.
+// The base URL is host-only (e.g. https://proxy.local or https://proxy.local/polygon);
+// a trailing version segment such as /v2 is still accepted and used as the version of relative endpoints.
 func WithBaseURL(baseURL string) ClientOption {
 	return func(client *Client) {
-		client.baseURL = baseURL
+		client.baseURL, client.apiVersion = splitBaseURL(baseURL)
 	}
 }
 
@@ -130,7 +150,7 @@ func WithWebsocketBaseURL(baseURL string) ClientOption {
 
 // GetJSON gets the JSON data from the given endpoint.
 func (c *Client) GetJSON(ctx context.Context, endpoint string, v any) error {
-	u, err := c.url(endpoint, map[string]string{"apiKey": c.token})
+	u, err := c.url(endpoint, c.authQueryParams())
 	if err != nil {
 		return err
 	}
@@ -139,7 +159,9 @@ func (c *Client) GetJSON(ctx context.Context, endpoint string, v any) error {
 
 // GetJSONWithQueryParams gets the JSON data from the given endpoint with the query parameters attached.
 func (c *Client) GetJSONWithQueryParams(ctx context.Context, endpoint string, queryParams map[string]string, v any) error {
-	queryParams["apiKey"] = c.token
+	if c.authMode == AuthQueryParam {
+		queryParams["apiKey"] = c.token
+	}
 	u, err := c.url(endpoint, queryParams)
 	if err != nil {
 		return err
@@ -149,7 +171,7 @@ func (c *Client) GetJSONWithQueryParams(ctx context.Context, endpoint string, qu
 
 // Fetches JSON content from the given URL and unmarshals it into `v`.
 func (c *Client) FetchURLToJSON(ctx context.Context, u *url.URL, v any) error {
-	data, err := c.getBytes(ctx, u.String())
+	data, err := c.cachedBytes(ctx, u)
 	if err != nil {
 		return err
 	}
@@ -169,11 +191,11 @@ func (c *Client) GetJSONWithoutToken(ctx context.Context, endpoint string, v any
 
 // GetBytes gets the data from the given endpoint.
 func (c *Client) GetBytes(ctx context.Context, endpoint string) ([]byte, error) {
-	u, err := c.url(endpoint, map[string]string{"apiKey": c.token})
+	u, err := c.url(endpoint, c.authQueryParams())
 	if err != nil {
 		return nil, err
 	}
-	return c.getBytes(ctx, u.String())
+	return c.loadBytes(ctx, u)
 }
 
 // GetFloat64 gets the number from the given endpoint.
@@ -186,33 +208,115 @@ func (c *Client) GetFloat64(ctx context.Context, endpoint string) (float64, erro
 }
 
 func (c *Client) getBytes(ctx context.Context, address string) ([]byte, error) {
+	policy := RetryPolicy{}
+	if c.retryPolicy != nil {
+		policy = *c.retryPolicy
+	}
+
+	for attempt := 1; ; attempt++ {
+		data, resp, err := c.doGetBytes(ctx, address)
+
+		retry := false
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		if err != nil && attempt < policy.attempts() && ctx.Err() == nil {
+			if resp != nil {
+				retry = policy.retryableStatus(resp.StatusCode)
+			} else {
+				retry = policy.retryableError(err)
+			}
+		}
+
+		var backoff time.Duration
+		if retry {
+			backoff = policy.backoff(attempt)
+			if resp != nil {
+				if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+					backoff = d
+				}
+			}
+		}
+
+		if policy.OnAttempt != nil {
+			policy.OnAttempt(RetryAttempt{
+				Attempt:    attempt,
+				URL:        c.redactURL(address),
+				StatusCode: statusCode,
+				Err:        err,
+				Backoff:    backoff,
+			})
+		}
+
+		if !retry {
+			return data, err
+		}
+
+		if err := sleepContext(ctx, backoff); err != nil {
+			return []byte{}, err
+		}
+	}
+}
+
+// doGetBytes makes a single GET request. The response is returned whenever one was received,
+// its body is already consumed and closed.
+func (c *Client) doGetBytes(ctx context.Context, address string) ([]byte, *http.Response, error) {
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return []byte{}, nil, err
+		}
+	}
+
 	req, err := http.NewRequest("GET", address, nil)
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, nil, err
+	}
+
+	if c.authMode == AuthHeader {
+		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
 
-	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	resp, err := c.roundTrip()(req.WithContext(ctx))
 	if err != nil {
-		return []byte{}, err
+		return []byte{}, nil, c.redactError(err)
 	}
 	defer resp.Body.Close()
 	// Even if GET didn't return an error, check the status code to make sure
 	// everything was ok.
 	if resp.StatusCode != http.StatusOK {
 		b, err := io.ReadAll(resp.Body)
-		msg := ""
-
-		if err == nil {
-			msg = string(b)
+		if err != nil {
+			b = nil
 		}
 
-		return []byte{}, Error{Status: resp.Status, ErrorMessage: msg}
+		return []byte{}, resp, c.newError(resp, b)
+	}
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return b, nil, err
 	}
-	return io.ReadAll(resp.Body)
+	return b, resp, nil
 }
 
-// Returns an URL object that points to the endpoint with optional query parameters.
+// fetchJSON gets the JSON data from the given fully versioned endpoint, e.g. /v3/reference/dividends.
+// name is the logical endpoint name reported to middlewares.
+func (c *Client) fetchJSON(ctx context.Context, name string, endpoint string, v any) error {
+	u, err := c.routeURL(endpoint, c.authQueryParams())
+	if err != nil {
+		return err
+	}
+	return c.FetchURLToJSON(WithEndpointName(ctx, name), u, v)
+}
+
+// Returns an URL object that points to the endpoint, relative to the current api version, with optional query parameters.
 func (c *Client) url(endpoint string, queryParams map[string]string) (*url.URL, error) {
+	return c.routeURL(c.apiVersion+endpoint, queryParams)
+}
+
+// Returns an URL object that points to the fully versioned endpoint with optional query parameters.
+func (c *Client) routeURL(endpoint string, queryParams map[string]string) (*url.URL, error) {
 	u, err := url.Parse(c.baseURL + endpoint)
 	if err != nil {
 		return nil, err
@@ -228,6 +332,39 @@ func (c *Client) url(endpoint string, queryParams map[string]string) (*url.URL,
 	return u, nil
 }
 
+// splitBaseURL splits a trailing version segment (e.g. /v2, /vX) off a base URL
+func splitBaseURL(baseURL string) (host string, version string) {
+	baseURL = strings.TrimRight(baseURL, "/")
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL, ""
+	}
+
+	i := strings.LastIndex(u.Path, "/")
+	if i < 0 || !isAPIVersion(u.Path[i+1:]) {
+		return baseURL, ""
+	}
+
+	version = u.Path[i:]
+	u.Path = u.Path[:i]
+	u.RawPath = ""
+	return u.String(), version
+}
+
+// isAPIVersion reports whether a path segment is a polygon api version such as v1, v2 or vX
+func isAPIVersion(segment string) bool {
+	if len(segment) < 2 || segment[0] != 'v' {
+		return false
+	}
+
+	if segment[1:] == "X" {
+		return true
+	}
+
+	_, err := strconv.Atoi(segment[1:])
+	return err == nil
+}
+
 func (c Client) endpointWithOpts(endpoint string, opts any) (string, error) {
 	if opts == nil {
 		return endpoint, nil
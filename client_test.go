@@ -0,0 +1,63 @@
+package polygon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitBaseURL(t *testing.T) {
+	cases := []struct {
+		baseURL string
+		host    string
+		version string
+	}{
+		{"https://api.polygon.io/v2", "https://api.polygon.io", "/v2"},
+		{"https://api.polygon.io", "https://api.polygon.io", ""},
+		{"https://v2.proxy.local/polygon/", "https://v2.proxy.local/polygon", ""},
+		{"http://localhost:8080/polygon/vX", "http://localhost:8080/polygon", "/vX"},
+		{"http://v2", "http://v2", ""},
+	}
+
+	for _, tc := range cases {
+		host, version := splitBaseURL(tc.baseURL)
+		assert.Equal(t, tc.host, host, tc.baseURL)
+		assert.Equal(t, tc.version, version, tc.baseURL)
+	}
+}
+
+func TestEndpointRouting(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write([]byte(`{"status":"OK","results":[{}],"results_count":1}`))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	for _, baseURL := range []string{srv.URL, srv.URL + "/v2"} {
+		paths = nil
+		client := NewClient("test-token", WithBaseURL(baseURL))
+		client.StockSplits(ctx, nil)
+		client.MarketStatus(ctx)
+		client.TickerEvent(ctx, "AAPL", nil)
+		client.IncomeStatements(ctx, nil)
+		client.PrevClose(ctx, "AAPL", nil)
+		v1 := client.UseV1Endpoints()
+		v1.GetJSON(ctx, "/marketstatus/now", &Market{})
+		client.GetJSON(ctx, "/aggs/ticker/AAPL/prev", &PrevClose{})
+
+		assert.Equal(t, []string{
+			"/v3/reference/splits",
+			"/v1/marketstatus/now",
+			"/vX/reference/tickers/AAPL/events",
+			"/stocks/financials/v1/income-statements",
+			"/v2/aggs/ticker/AAPL/prev",
+			"/v1/marketstatus/now",
+			"/v2/aggs/ticker/AAPL/prev",
+		}, paths, baseURL)
+	}
+}
@@ -0,0 +1,91 @@
+package polygon
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"sync"
+)
+
+// coalescer shares a single in-flight request between identical concurrent calls.
+// It is shared by all copies of a client.
+type coalescer struct {
+	mu    sync.Mutex
+	calls map[string]*coalescedCall
+}
+
+type coalescedCall struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	data    []byte
+	err     error
+}
+
+// WithRequestCoalescing deduplicates identical concurrent GET requests of a new Polygon Client.
+// Requests are keyed on the normalized URL without the API key, every caller keeps its own cancellation:
+// the shared request is only cancelled once all of its callers are gone.
+func WithRequestCoalescing() ClientOption {
+	return func(client *Client) {
+		client.coalescer = &coalescer{calls: make(map[string]*coalescedCall)}
+	}
+}
+
+// loadBytes gets the data of the given URL, sharing the request with identical concurrent calls if enabled
+func (c *Client) loadBytes(ctx context.Context, u *url.URL) ([]byte, error) {
+	if c.coalescer == nil {
+		return c.getBytes(ctx, u.String())
+	}
+
+	return c.coalescer.do(ctx, cacheKey(u), func(ctx context.Context) ([]byte, error) {
+		return c.getBytes(ctx, u.String())
+	})
+}
+
+func (g *coalescer) do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return []byte{}, err
+	}
+
+	g.mu.Lock()
+	call, ok := g.calls[key]
+	if !ok {
+		// the shared request outlives the caller that started it
+		sharedCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &coalescedCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+
+		go func() {
+			call.data, call.err = fn(sharedCtx)
+			g.forget(key, call)
+			cancel()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return slices.Clone(call.data), call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return []byte{}, ctx.Err()
+	}
+}
+
+func (g *coalescer) forget(key string, call *coalescedCall) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
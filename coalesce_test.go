@@ -0,0 +1,68 @@
+package polygon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestCoalescing(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Write([]byte(`{"status":"OK","count":1,"results":[{"T":"AAPL","c":1.5}]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("test-token", WithBaseURL(srv.URL), WithRequestCoalescing())
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p, err := client.PrevClose(context.Background(), "AAPL", nil)
+			assert.NoError(t, err)
+			assert.Equal(t, 1.5, p.Results[0].Close)
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRequestCoalescingIndependentCancellation(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{"status":"OK","results":[]}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("test-token", WithBaseURL(srv.URL), WithRequestCoalescing())
+
+	done := make(chan error)
+	go func() {
+		_, err := client.Summary(context.Background(), []SummaryAsset{{Ticker: "AAPL", AssetType: "stock"}})
+		done <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.Summary(ctx, []SummaryAsset{{Ticker: "AAPL", AssetType: "stock"}})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	assert.NoError(t, <-done)
+}
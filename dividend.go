@@ -3,6 +3,7 @@ package polygon
 import (
 	"context"
 	"fmt"
+	"iter"
 )
 
 // Dividend Get a list of historical cash dividends, including the ticker symbol, declaration date, ex-dividend date, record date, pay date, frequency, and amount.
@@ -26,6 +27,16 @@ type DividendResult struct {
 	Ticker          string  `json:"ticker"`
 }
 
+// PageItems implements Page
+func (d Dividend) PageItems() []DividendResult {
+	return d.Results
+}
+
+// NextPageURL implements Page
+func (d Dividend) NextPageURL() string {
+	return d.NextURL
+}
+
 type DividendType string
 
 const (
@@ -64,7 +75,6 @@ type DividendOption struct {
 
 // Dividend Get a list of historical cash dividends, including the ticker symbol, declaration date, ex-dividend date, record date, pay date, frequency, and amount.
 func (c Client) Dividend(ctx context.Context, ticker string, opt *DividendOption) (Dividend, error) {
-	c = c.UseV3Endpoints()
 	d := Dividend{}
 
 	if opt == nil {
@@ -72,14 +82,21 @@ func (c Client) Dividend(ctx context.Context, ticker string, opt *DividendOption
 	}
 
 	opt.Ticker = ticker
-	endpoint, err := c.endpointWithOpts("/reference/dividends", opt)
+	endpoint, err := c.endpointWithOpts("/v3/reference/dividends", opt)
 	if err != nil {
 		return d, err
 	}
-	err = c.GetJSON(ctx, endpoint, &d)
+	err = c.fetchJSON(ctx, "Dividend", endpoint, &d)
 	return d, err
 }
 
+// DividendIter iterates over all dividends for a given ticker, following next_url across pages
+func (c Client) DividendIter(ctx context.Context, ticker string, opt *DividendOption, opts ...PaginationOption) iter.Seq2[DividendResult, error] {
+	return Paginate(WithEndpointName(ctx, "Dividend"), &c, func(ctx context.Context) (Dividend, error) {
+		return c.Dividend(ctx, ticker, opt)
+	}, opts...)
+}
+
 // LastestDiviend retrieves the latest dividend for a given ticker
 func (c Client) LastestDiviend(ctx context.Context, ticker string, opt *DividendOption) (DividendResult, error) {
 	if opt == nil {
@@ -96,11 +113,11 @@ func (c Client) LastestDiviend(ctx context.Context, ticker string, opt *Dividend
 	}
 
 	if len(d.Results) == 0 {
-		return DividendResult{}, fmt.Errorf("no dividend found for %s", ticker)
+		return DividendResult{}, fmt.Errorf("no dividend found for %s: %w", ticker, ErrNoResults)
 	}
 
 	if d.Status != "OK" {
-		return DividendResult{}, fmt.Errorf("status is not OK: %s", d.Status)
+		return DividendResult{}, fmt.Errorf("status is not OK: %s: %w", d.Status, ErrUnexpectedStatus)
 	}
 
 	return d.Results[0], nil
@@ -2,14 +2,14 @@ package polygon
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 	"strings"
 )
 
 var (
-	ErrEMAStatus    = errors.New("ema: unexpected status")
-	ErrEMANoResults = errors.New("ema: no results")
+	ErrEMAStatus    = fmt.Errorf("ema: %w", ErrUnexpectedStatus)
+	ErrEMANoResults = fmt.Errorf("ema: %w", ErrNoResults)
 )
 
 // EMAOption options for fetching EMA
@@ -32,26 +32,32 @@ type EMAResponse struct {
 		Underlying struct {
 			URL string `json:"url"`
 		} `json:"underlying"`
-		Values []struct {
-			Timestamp int64   `json:"timestamp"`
-			Value     float64 `json:"value"`
-		} `json:"values"`
+		Values []IndicatorValue `json:"values"`
 	} `json:"results"`
 	Status    string `json:"status"`
 	RequestID string `json:"request_id"`
 	NextURL   string `json:"next_url"`
 }
 
+// PageItems implements Page
+func (r EMAResponse) PageItems() []IndicatorValue {
+	return r.Results.Values
+}
+
+// NextPageURL implements Page
+func (r EMAResponse) NextPageURL() string {
+	return r.NextURL
+}
+
 // ExponentialMovingAverage get stock EMA for a given ticker
 func (c Client) ExponentialMovingAverage(ctx context.Context, ticker string, opt *EMAOption) (resp EMAResponse, err error) {
-	c = c.UseV1Endpoints()
 	ticker = strings.ToUpper(strings.TrimSpace(ticker))
-	endpoint, err := c.endpointWithOpts("/indicators/ema/"+ticker, opt)
+	endpoint, err := c.endpointWithOpts("/v1/indicators/ema/"+ticker, opt)
 	if err != nil {
 		return
 	}
 
-	if err = c.GetJSON(ctx, endpoint, &resp); err != nil {
+	if err = c.fetchJSON(ctx, "ExponentialMovingAverage", endpoint, &resp); err != nil {
 		err = fmt.Errorf("get json: %w", err)
 		return
 	}
@@ -68,3 +74,10 @@ func (c Client) ExponentialMovingAverage(ctx context.Context, ticker string, opt
 
 	return resp, err
 }
+
+// ExponentialMovingAverageIter iterates over all EMA values for a given ticker, following next_url across pages
+func (c Client) ExponentialMovingAverageIter(ctx context.Context, ticker string, opt *EMAOption, opts ...PaginationOption) iter.Seq2[IndicatorValue, error] {
+	return Paginate(WithEndpointName(ctx, "ExponentialMovingAverage"), &c, func(ctx context.Context) (EMAResponse, error) {
+		return c.ExponentialMovingAverage(ctx, ticker, opt)
+	}, opts...)
+}
@@ -0,0 +1,113 @@
+package polygon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrNotAuthorized the API key is missing, unknown or not allowed to access the resource
+	ErrNotAuthorized = errors.New("not authorized")
+	// ErrPlanNotIncluded the endpoint or data is not included in the current plan
+	ErrPlanNotIncluded = errors.New("not included in plan")
+	// ErrNotFound the requested resource does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrRateLimited too many requests were made
+	ErrRateLimited = errors.New("rate limited")
+	// ErrServerError polygon failed to serve the request
+	ErrServerError = errors.New("server error")
+
+	// ErrUnexpectedStatus the response status is not OK
+	ErrUnexpectedStatus = errors.New("unexpected status")
+	// ErrNoResults the response does not contain any result
+	ErrNoResults = errors.New("no results")
+)
+
+// Error represents an Polygon API error
+type Error struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode   int    `json:"-"`
+	Status       string `json:"status"`
+	ErrorMessage string `json:"error"`
+	RequestID    string `json:"request_id"`
+}
+
+// Error implements the error interface
+func (e Error) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("%s: %s (request id: %s)", e.Status, e.ErrorMessage, e.RequestID)
+	}
+	return fmt.Sprintf("%d %s: %s (request id: %s)", e.StatusCode, e.Status, e.ErrorMessage, e.RequestID)
+}
+
+// Is classifies the error so it can be matched with errors.Is against
+// ErrNotAuthorized, ErrPlanNotIncluded, ErrNotFound, ErrRateLimited and ErrServerError.
+// A 403 caused by a plan restriction only matches ErrPlanNotIncluded.
+func (e Error) Is(target error) bool {
+	switch target {
+	case ErrNotAuthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden && !e.planNotIncluded()
+	case ErrPlanNotIncluded:
+		return e.StatusCode == http.StatusForbidden && e.planNotIncluded()
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrServerError:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// planNotIncluded polygon answers "You are not entitled to this data. Please upgrade your plan at https://polygon.io/pricing"
+// for plan restrictions
+func (e Error) planNotIncluded() bool {
+	msg := strings.ToLower(e.ErrorMessage)
+	return strings.Contains(msg, "not entitled") || strings.Contains(msg, "upgrade your plan")
+}
+
+// IsNotAuthorized reports whether err is caused by a missing, unknown or unauthorized API key
+func IsNotAuthorized(err error) bool { return errors.Is(err, ErrNotAuthorized) }
+
+// IsPlanNotIncluded reports whether err is caused by an endpoint not included in the plan
+func IsPlanNotIncluded(err error) bool { return errors.Is(err, ErrPlanNotIncluded) }
+
+// IsNotFound reports whether err is caused by a resource that does not exist
+func IsNotFound(err error) bool { return errors.Is(err, ErrNotFound) }
+
+// IsRateLimited reports whether err is caused by too many requests
+func IsRateLimited(err error) bool { return errors.Is(err, ErrRateLimited) }
+
+// IsServerError reports whether err is caused by a polygon server failure
+func IsServerError(err error) bool { return errors.Is(err, ErrServerError) }
+
+// newError decodes a polygon error body, falling back to the raw body when it is not JSON
+func (c *Client) newError(resp *http.Response, body []byte) Error {
+	e := Error{StatusCode: resp.StatusCode}
+
+	var payload struct {
+		Status    string `json:"status"`
+		Error     string `json:"error"`
+		Message   string `json:"message"`
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		e.Status = payload.Status
+		e.ErrorMessage = payload.Error
+		if e.ErrorMessage == "" {
+			e.ErrorMessage = payload.Message
+		}
+		e.RequestID = payload.RequestID
+	} else {
+		e.ErrorMessage = string(body)
+	}
+
+	if e.Status == "" {
+		e.Status = http.StatusText(resp.StatusCode)
+	}
+	e.ErrorMessage = c.redact(e.ErrorMessage)
+	return e
+}
@@ -0,0 +1,69 @@
+package polygon
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorDecodedFromBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"status":"NOT_AUTHORIZED","request_id":"abc123","message":"You are not entitled to this data. Please upgrade your plan at https://polygon.io/pricing"}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient("test-token", WithBaseURL(srv.URL))
+	_, err := client.GetBytes(context.Background(), "/value")
+
+	var apiErr Error
+	if assert.True(t, errors.As(err, &apiErr)) {
+		assert.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+		assert.Equal(t, "NOT_AUTHORIZED", apiErr.Status)
+		assert.Equal(t, "abc123", apiErr.RequestID)
+		assert.Contains(t, apiErr.ErrorMessage, "not entitled")
+	}
+	assert.True(t, IsPlanNotIncluded(err))
+	assert.False(t, IsNotAuthorized(err))
+	assert.False(t, IsNotFound(err))
+}
+
+func TestErrorForbiddenNotPlan(t *testing.T) {
+	err := error(Error{StatusCode: http.StatusForbidden, ErrorMessage: "Unknown API Key"})
+	assert.True(t, IsNotAuthorized(err))
+	assert.False(t, IsPlanNotIncluded(err))
+
+	// "plan" alone is not polygon's entitlement wording
+	err = Error{StatusCode: http.StatusForbidden, ErrorMessage: "key disabled by plan administrator"}
+	assert.True(t, IsNotAuthorized(err))
+	assert.False(t, IsPlanNotIncluded(err))
+}
+
+func TestErrorClassification(t *testing.T) {
+	cases := []struct {
+		code   int
+		target error
+	}{
+		{http.StatusUnauthorized, ErrNotAuthorized},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusBadGateway, ErrServerError},
+	}
+
+	for _, tc := range cases {
+		err := error(Error{StatusCode: tc.code})
+		assert.ErrorIs(t, err, tc.target, "status %d", tc.code)
+		assert.NotErrorIs(t, err, ErrPlanNotIncluded, "status %d", tc.code)
+	}
+}
+
+func TestEndpointErrorsUnified(t *testing.T) {
+	assert.ErrorIs(t, ErrRSINoResults, ErrNoResults)
+	assert.ErrorIs(t, ErrSMAStatus, ErrUnexpectedStatus)
+	assert.ErrorIs(t, ErrIncomeStatementsNoResults, ErrNoResults)
+	assert.ErrorIs(t, ErrFinancialsNoResults, ErrNoResults)
+}
@@ -2,8 +2,8 @@ package polygon
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 	"strings"
 )
 
@@ -15,6 +15,16 @@ type Financials struct {
 	NextURL   string            `json:"next_url"`
 }
 
+// PageItems implements Page
+func (f Financials) PageItems() []FinancialResult {
+	return f.Results
+}
+
+// NextPageURL implements Page
+func (f Financials) NextPageURL() string {
+	return f.NextURL
+}
+
 // FinancialResult result (one filing/period)
 type FinancialResult struct {
 	StartDate    string              `json:"start_date"`    // e.g., "2009-06-28"
@@ -83,23 +93,22 @@ type FinancialsOption struct {
 	Sort               string                    `url:"sort,omitempty"`                  // Sort field used for ordering
 }
 
-var ErrFinancialsNoResults = errors.New("no financials results")
+var ErrFinancialsNoResults = fmt.Errorf("financials: %w", ErrNoResults)
 
 // Financials Retrieve historical financial data for a specified stock ticker
 //
 // Deprecated: This API is deprecated and will be removed in a future version.
 func (c Client) Financials(ctx context.Context, ticker string, opt *FinancialsOption) (resp Financials, err error) {
-	c = c.UseVXEndpoints()
 	ticker = strings.ToUpper(strings.TrimSpace(ticker))
 	if opt == nil {
 		opt = new(FinancialsOption)
 	}
 	opt.Ticker = ticker
-	endpoint, err := c.endpointWithOpts("/reference/financials", opt)
+	endpoint, err := c.endpointWithOpts("/vX/reference/financials", opt)
 	if err != nil {
 		return
 	}
-	if err = c.GetJSON(ctx, endpoint, &resp); err != nil {
+	if err = c.fetchJSON(ctx, "Financials", endpoint, &resp); err != nil {
 		err = fmt.Errorf("get json: %w", err)
 		return
 	}
@@ -113,3 +122,12 @@ func (c Client) Financials(ctx context.Context, ticker string, opt *FinancialsOp
 	}
 	return resp, err
 }
+
+// FinancialsIter iterates over all financials for a given ticker, following next_url across pages
+//
+// Deprecated: This API is deprecated and will be removed in a future version.
+func (c Client) FinancialsIter(ctx context.Context, ticker string, opt *FinancialsOption, opts ...PaginationOption) iter.Seq2[FinancialResult, error] {
+	return Paginate(WithEndpointName(ctx, "Financials"), &c, func(ctx context.Context) (Financials, error) {
+		return c.Financials(ctx, ticker, opt)
+	}, opts...)
+}
@@ -24,3 +24,9 @@ const Closed MarketStatus = "closed"
 const EarlyHours MarketStatus = "early_hours"
 const AfterHours MarketStatus = "after_hours"
 const Overnight MarketStatus = "overnight"
+
+// IndicatorValue technical indicator (SMA, EMA, RSI) value item
+type IndicatorValue struct {
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
@@ -2,8 +2,8 @@ package polygon
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 	"strings"
 )
 
@@ -24,6 +24,16 @@ type IncomeStatementsResponse struct {
 	NextURL   string            `json:"next_url"`
 }
 
+// PageItems implements Page
+func (r IncomeStatementsResponse) PageItems() []IncomeStatement {
+	return r.Results
+}
+
+// NextPageURL implements Page
+func (r IncomeStatementsResponse) NextPageURL() string {
+	return r.NextURL
+}
+
 // IncomeStatement represents a single income statement period (annual, quarterly, or trailing twelve months)
 // Only a subset of fields are modeled explicitly; additional numeric fields can be added as needed.
 // The Polygon docs list these numeric metrics as numbers; we use float64. Optional fields default to zero if missing.
@@ -79,19 +89,18 @@ type IncomeStatementsOption struct {
 	Sort          string                    `url:"sort,omitempty"`      // e.g. period_end.desc
 }
 
-var ErrIncomeStatementsNoResults = errors.New("no income statements results")
+var ErrIncomeStatementsNoResults = fmt.Errorf("income statements: %w", ErrNoResults)
 
 // IncomeStatements retrieves income statements data. This replaces the deprecated Financials endpoint.
 func (c Client) IncomeStatements(ctx context.Context, opt *IncomeStatementsOption) (resp IncomeStatementsResponse, err error) {
-	c = c.UseFinancialsV1Endpoints()
 	if opt == nil {
 		opt = new(IncomeStatementsOption)
 	}
-	endpoint, err := c.endpointWithOpts("/income-statements", opt)
+	endpoint, err := c.endpointWithOpts("/stocks/financials/v1/income-statements", opt)
 	if err != nil {
 		return
 	}
-	if err = c.GetJSON(ctx, endpoint, &resp); err != nil {
+	if err = c.fetchJSON(ctx, "IncomeStatements", endpoint, &resp); err != nil {
 		err = fmt.Errorf("get json: %w", err)
 		return
 	}
@@ -106,6 +115,13 @@ func (c Client) IncomeStatements(ctx context.Context, opt *IncomeStatementsOptio
 	return
 }
 
+// IncomeStatementsIter iterates over all income statements, following next_url across pages
+func (c Client) IncomeStatementsIter(ctx context.Context, opt *IncomeStatementsOption, opts ...PaginationOption) iter.Seq2[IncomeStatement, error] {
+	return Paginate(WithEndpointName(ctx, "IncomeStatements"), &c, func(ctx context.Context) (IncomeStatementsResponse, error) {
+		return c.IncomeStatements(ctx, opt)
+	}, opts...)
+}
+
 // GetRevenue helper returns the revenue for a statement period.
 func (is IncomeStatement) GetRevenue() float64 { return is.Revenue }
 
@@ -65,13 +65,11 @@ type MarketOption struct{}
 
 // MarketStatus Get the current trading status of the exchanges and overall financial markets.
 func (c Client) MarketStatus(ctx context.Context) (Market, error) {
-	c = c.UseV1Endpoints()
-
 	m := Market{}
-	endpoint, err := c.endpointWithOpts("/marketstatus/now", new(MarketOption))
+	endpoint, err := c.endpointWithOpts("/v1/marketstatus/now", new(MarketOption))
 	if err != nil {
 		return m, err
 	}
-	err = c.GetJSON(ctx, endpoint, &m)
+	err = c.fetchJSON(ctx, "MarketStatus", endpoint, &m)
 	return m, err
 }
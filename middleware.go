@@ -0,0 +1,113 @@
+package polygon
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// RoundTripFunc performs a single HTTP request
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware intercepts every request made by the client, RoundTripper style.
+// The logical endpoint name is available through EndpointName(req.Context()).
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// RequestEvent describes a completed request
+type RequestEvent struct {
+	// Endpoint is the logical endpoint name, e.g. "Aggregation" or "Dividend"
+	Endpoint string
+	Request  *http.Request
+	// Response is nil when the request failed
+	Response *http.Response
+	Latency  time.Duration
+	Err      error
+}
+
+type endpointNameKey struct{}
+
+// WithMiddleware adds middlewares to a new Polygon Client.
+// The first middleware is the outermost one, it sees the request first and the response last.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(client *Client) {
+		client.middlewares = append(client.middlewares, middlewares...)
+	}
+}
+
+// WithEndpointName returns a context carrying the logical endpoint name reported to middlewares
+func WithEndpointName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, endpointNameKey{}, name)
+}
+
+// EndpointName returns the logical endpoint name carried by ctx, empty if none
+func EndpointName(ctx context.Context) string {
+	name, _ := ctx.Value(endpointNameKey{}).(string)
+	return name
+}
+
+// ObserveMiddleware calls fn once every request has completed
+func ObserveMiddleware(fn func(RequestEvent)) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			fn(RequestEvent{
+				Endpoint: EndpointName(req.Context()),
+				Request:  req,
+				Response: resp,
+				Latency:  time.Since(start),
+				Err:      err,
+			})
+			return resp, err
+		}
+	}
+}
+
+// LoggingMiddleware logs every request with the given structured logger, the API key is redacted from URLs
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return ObserveMiddleware(func(e RequestEvent) {
+		attrs := []slog.Attr{
+			slog.String("endpoint", e.Endpoint),
+			slog.String("method", e.Request.Method),
+			slog.String("url", RedactURL(e.Request.URL.String())),
+			slog.Duration("latency", e.Latency),
+		}
+
+		ctx := e.Request.Context()
+		switch {
+		case e.Err != nil:
+			attrs = append(attrs, slog.String("error", e.Err.Error()))
+			logger.LogAttrs(ctx, slog.LevelError, "polygon request failed", attrs...)
+		case e.Response.StatusCode >= http.StatusBadRequest:
+			attrs = append(attrs, slog.Int("status", e.Response.StatusCode))
+			logger.LogAttrs(ctx, slog.LevelWarn, "polygon request failed", attrs...)
+		default:
+			attrs = append(attrs, slog.Int("status", e.Response.StatusCode))
+			logger.LogAttrs(ctx, slog.LevelInfo, "polygon request", attrs...)
+		}
+	})
+}
+
+// TimingMiddleware reports the latency of every request, e.g. to feed a metrics histogram
+func TimingMiddleware(fn func(endpoint string, latency time.Duration)) Middleware {
+	return ObserveMiddleware(func(e RequestEvent) {
+		fn(e.Endpoint, e.Latency)
+	})
+}
+
+// roundTrip composes the middlewares around the http client.
+// Transport errors are redacted first, so no middleware sees the API key in an error.
+func (c *Client) roundTrip() RoundTripFunc {
+	rt := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return resp, c.redactError(err)
+		}
+		return resp, nil
+	})
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		rt = c.middlewares[i](rt)
+	}
+	return rt
+}
@@ -0,0 +1,102 @@
+package polygon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "trace-1", r.Header.Get("X-Trace-Id"))
+		w.Write([]byte(`{"status":"OK","results":[{"ticker":"AAPL"}]}`))
+	}))
+	defer srv.Close()
+
+	var order []string
+	var events []RequestEvent
+	tracing := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			order = append(order, "tracing")
+			req.Header.Set("X-Trace-Id", "trace-1")
+			return next(req)
+		}
+	}
+
+	client := NewClient("test-token", WithBaseURL(srv.URL), WithMiddleware(
+		tracing,
+		ObserveMiddleware(func(e RequestEvent) {
+			order = append(order, "observe")
+			events = append(events, e)
+		}),
+	))
+
+	_, err := client.Dividend(context.Background(), "AAPL", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tracing", "observe"}, order)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "Dividend", events[0].Endpoint)
+		assert.Equal(t, http.StatusOK, events[0].Response.StatusCode)
+		assert.Positive(t, events[0].Latency)
+	}
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	errInjected := errors.New("injected")
+	client := NewClient("test-token", WithMiddleware(func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return nil, errInjected
+		}
+	}))
+
+	_, err := client.PrevClose(context.Background(), "AAPL", nil)
+	assert.ErrorIs(t, err, errInjected)
+}
+
+func TestLoggingAndTimingMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	var timed []string
+	client := NewClient("secret-token", WithBaseURL(srv.URL), WithMiddleware(
+		LoggingMiddleware(slog.New(slog.NewTextHandler(&buf, nil))),
+		TimingMiddleware(func(endpoint string, latency time.Duration) {
+			timed = append(timed, endpoint)
+		}),
+	))
+
+	_, err := client.TickerDetail(context.Background(), "AAPL", nil)
+	assert.True(t, IsNotFound(err))
+	assert.Equal(t, []string{"TickerDetail"}, timed)
+	assert.Contains(t, buf.String(), "endpoint=TickerDetail")
+	assert.Contains(t, buf.String(), "status=404")
+	assert.NotContains(t, buf.String(), "secret-token")
+}
+
+func TestLoggingMiddlewareRedactsTransportError(t *testing.T) {
+	// a closed server refuses connections
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	var buf bytes.Buffer
+	client := NewClient("SECRETKEY123", WithBaseURL(srv.URL), WithMiddleware(
+		LoggingMiddleware(slog.New(slog.NewTextHandler(&buf, nil))),
+	))
+
+	_, err := client.TickerDetail(context.Background(), "AAPL", nil)
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "SECRETKEY123")
+	assert.Contains(t, buf.String(), "polygon request failed")
+	assert.Contains(t, buf.String(), "REDACTED")
+	assert.NotContains(t, buf.String(), "SECRETKEY123")
+}
@@ -2,31 +2,45 @@ package polygon
 
 import (
 	"context"
+	"iter"
 )
 
 // News models a news item either for the market or for an individual stock.
 type News struct {
-	Results []struct {
-		ID        string `json:"id"`
-		Publisher struct {
-			Name     string `json:"name"`
-			Homepage string `json:"homepage_url"`
-			Logo     string `json:"logo_url"`
-			Favicon  string `json:"favicon_url"`
-		} `json:"publisher"`
-		Title        string   `json:"title"`
-		Author       string   `json:"author"`
-		PublishedUTC string   `json:"published_utc"`
-		ArticleURL   string   `json:"article_url"`
-		AmpURL       string   `json:"amp_url"`
-		Tickers      []string `json:"tickers"`
-		ImageURL     string   `json:"image_url"`
-		Description  string   `json:"description"`
-		Keywords     []string `json:"keywords"`
-	} `json:"results"`
-	Status  string `json:"status"`
-	Count   int    `json:"count"`
-	NextURL string `json:"next_url"`
+	Results []NewsResult `json:"results"`
+	Status  string       `json:"status"`
+	Count   int          `json:"count"`
+	NextURL string       `json:"next_url"`
+}
+
+// NewsResult news result item
+type NewsResult struct {
+	ID        string `json:"id"`
+	Publisher struct {
+		Name     string `json:"name"`
+		Homepage string `json:"homepage_url"`
+		Logo     string `json:"logo_url"`
+		Favicon  string `json:"favicon_url"`
+	} `json:"publisher"`
+	Title        string   `json:"title"`
+	Author       string   `json:"author"`
+	PublishedUTC string   `json:"published_utc"`
+	ArticleURL   string   `json:"article_url"`
+	AmpURL       string   `json:"amp_url"`
+	Tickers      []string `json:"tickers"`
+	ImageURL     string   `json:"image_url"`
+	Description  string   `json:"description"`
+	Keywords     []string `json:"keywords"`
+}
+
+// PageItems implements Page
+func (n News) PageItems() []NewsResult {
+	return n.Results
+}
+
+// NextPageURL implements Page
+func (n News) NextPageURL() string {
+	return n.NextURL
 }
 
 // NewsOption option for fetching news
@@ -49,11 +63,18 @@ func (c Client) News(ctx context.Context, ticker string, opt *NewsOption) (News,
 	opt.Ticker = ticker
 
 	n := News{}
-	endpoint, err := c.endpointWithOpts("/reference/news", opt)
+	endpoint, err := c.endpointWithOpts("/v2/reference/news", opt)
 	if err != nil {
 		return n, err
 	}
 
-	err = c.GetJSON(ctx, endpoint, &n)
+	err = c.fetchJSON(ctx, "News", endpoint, &n)
 	return n, err
 }
+
+// NewsIter iterates over all news articles for the given stock symbol, following next_url across pages
+func (c Client) NewsIter(ctx context.Context, ticker string, opt *NewsOption, opts ...PaginationOption) iter.Seq2[NewsResult, error] {
+	return Paginate(WithEndpointName(ctx, "News"), &c, func(ctx context.Context) (News, error) {
+		return c.News(ctx, ticker, opt)
+	}, opts...)
+}
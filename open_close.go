@@ -26,25 +26,23 @@ type OpenCloseOption struct {
 
 // StockOpenClose Get the open, close and afterhours prices of a stock symbol on a certain date.
 func (c Client) StockOpenClose(ctx context.Context, ticker string, date string, opt *OpenCloseOption) (OpenClose, error) {
-	c = c.UseV1Endpoints()
 	p := OpenClose{}
 
-	endpoint, err := c.endpointWithOpts(fmt.Sprintf("/open-close/%s/%s", ticker, date), opt)
+	endpoint, err := c.endpointWithOpts(fmt.Sprintf("/v1/open-close/%s/%s", ticker, date), opt)
 	if err != nil {
 		return p, err
 	}
-	err = c.GetJSON(ctx, endpoint, &p)
+	err = c.fetchJSON(ctx, "StockOpenClose", endpoint, &p)
 	return p, err
 }
 
 // CryptoOpenClose Get the open, close prices of a crypto pair on a certain date.
 func (c Client) CryptoOpenClose(ctx context.Context, from, to string, date string, opt *OpenCloseOption) (OpenClose, error) {
-	c = c.UseV1Endpoints()
 	p := OpenClose{}
-	endpoint, err := c.endpointWithOpts(fmt.Sprintf("/open-close/crypto/%s/%s/%s", from, to, date), opt)
+	endpoint, err := c.endpointWithOpts(fmt.Sprintf("/v1/open-close/crypto/%s/%s/%s", from, to, date), opt)
 	if err != nil {
 		return p, err
 	}
-	err = c.GetJSON(ctx, endpoint, &p)
+	err = c.fetchJSON(ctx, "CryptoOpenClose", endpoint, &p)
 	return p, err
 }
@@ -0,0 +1,101 @@
+package polygon
+
+import (
+	"context"
+	"iter"
+	"net/url"
+)
+
+// Page is implemented by list responses that carry a next_url cursor.
+type Page[T any] interface {
+	// PageItems returns the items contained in the page
+	PageItems() []T
+	// NextPageURL returns the cursor of the next page, empty on the last page
+	NextPageURL() string
+}
+
+// PaginationOption applies an option to a paginated iterator.
+type PaginationOption func(*paginationConfig)
+
+type paginationConfig struct {
+	maxItems int
+}
+
+// WithMaxItems stops the iteration once n items have been yielded. Zero or less means no cap.
+func WithMaxItems(n int) PaginationOption {
+	return func(cfg *paginationConfig) {
+		cfg.maxItems = n
+	}
+}
+
+// Paginate returns an iterator over every item of a list endpoint.
+// The first page is fetched by first, following pages are fetched lazily from next_url
+// as the caller keeps consuming items. Iteration stops after the first error is yielded.
+func Paginate[P Page[T], T any](ctx context.Context, c *Client, first func(context.Context) (P, error), opts ...PaginationOption) iter.Seq2[T, error] {
+	cfg := paginationConfig{}
+	for _, applyOption := range opts {
+		applyOption(&cfg)
+	}
+
+	return func(yield func(T, error) bool) {
+		var zero T
+		if err := ctx.Err(); err != nil {
+			yield(zero, err)
+			return
+		}
+
+		page, err := first(ctx)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+
+		count := 0
+		for {
+			for _, item := range page.PageItems() {
+				if !yield(item, nil) {
+					return
+				}
+
+				count++
+				if cfg.maxItems > 0 && count >= cfg.maxItems {
+					return
+				}
+			}
+
+			next := page.NextPageURL()
+			if next == "" {
+				return
+			}
+
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			var p P
+			if err := c.FetchNextURL(ctx, next, &p); err != nil {
+				yield(zero, err)
+				return
+			}
+			page = p
+		}
+	}
+}
+
+// FetchNextURL fetches a next_url pagination cursor and unmarshals it into `v`.
+// Polygon cursors do not carry the API key, so it is attached here
+// (the Authorization header is set on every request when using AuthHeader).
+func (c *Client) FetchNextURL(ctx context.Context, next string, v any) error {
+	u, err := url.Parse(next)
+	if err != nil {
+		return err
+	}
+
+	if c.authMode == AuthQueryParam {
+		q := u.Query()
+		q.Set("apiKey", c.token)
+		u.RawQuery = q.Encode()
+	}
+	return c.FetchURLToJSON(ctx, u, v)
+}
@@ -0,0 +1,75 @@
+package polygon
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDividendPagesServer(t *testing.T, pages int) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "test-token", r.URL.Query().Get("apiKey"))
+
+		cursor := 0
+		fmt.Sscan(r.URL.Query().Get("cursor"), &cursor)
+		next := ""
+		if cursor+1 < pages {
+			next = fmt.Sprintf("%s/v3/reference/dividends?cursor=%d", srv.URL, cursor+1)
+		}
+		fmt.Fprintf(w, `{"status":"OK","next_url":%q,"results":[{"ticker":"AAPL","frequency":%d},{"ticker":"AAPL","frequency":%d}]}`, next, cursor*2, cursor*2+1)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestPaginateFollowsNextURL(t *testing.T) {
+	srv := newDividendPagesServer(t, 3)
+	client := NewClient("test-token", WithBaseURL(srv.URL+"/v2"))
+
+	var frequencies []int
+	for d, err := range client.DividendIter(context.Background(), "AAPL", nil) {
+		if !assert.NoError(t, err) {
+			return
+		}
+		frequencies = append(frequencies, d.Frequency)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5}, frequencies)
+}
+
+func TestPaginateMaxItems(t *testing.T) {
+	srv := newDividendPagesServer(t, 3)
+	client := NewClient("test-token", WithBaseURL(srv.URL+"/v2"))
+
+	count := 0
+	for _, err := range client.DividendIter(context.Background(), "AAPL", nil, WithMaxItems(3)) {
+		assert.NoError(t, err)
+		count++
+	}
+	assert.Equal(t, 3, count)
+}
+
+func TestPaginateContextCancelled(t *testing.T) {
+	srv := newDividendPagesServer(t, 3)
+	client := NewClient("test-token", WithBaseURL(srv.URL+"/v2"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var lastErr error
+	count := 0
+	for _, err := range client.DividendIter(ctx, "AAPL", nil) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		count++
+		cancel()
+	}
+	assert.Equal(t, 2, count)
+	assert.ErrorIs(t, lastErr, context.Canceled)
+}
@@ -50,10 +50,10 @@ type PrevCloseOption struct {
 func (c Client) PrevClose(ctx context.Context, ticker string, opt *PrevCloseOption) (PrevClose, error) {
 	p := PrevClose{}
 
-	endpoint, err := c.endpointWithOpts(fmt.Sprintf("/aggs/ticker/%s/prev", ticker), opt)
+	endpoint, err := c.endpointWithOpts(fmt.Sprintf("/v2/aggs/ticker/%s/prev", ticker), opt)
 	if err != nil {
 		return p, err
 	}
-	err = c.GetJSON(ctx, endpoint, &p)
+	err = c.fetchJSON(ctx, "PrevClose", endpoint, &p)
 	return p, err
 }
@@ -0,0 +1,113 @@
+package polygon
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimit describes a request budget
+type RateLimit struct {
+	// Requests is the number of requests allowed per period
+	Requests int
+	// Per is the period over which Requests are allowed
+	Per time.Duration
+	// Burst is the number of requests that can be made at once, defaults to Requests
+	Burst int
+}
+
+var (
+	// RateLimitBasic free (Basic) plan: 5 requests per minute
+	RateLimitBasic = RateLimit{Requests: 5, Per: time.Minute}
+	// RateLimitPaid paid plans (Starter and above) are not capped, but polygon asks to stay under 100 requests per second
+	RateLimitPaid = RateLimit{Requests: 100, Per: time.Second}
+)
+
+// RateLimiter is a token bucket shared by every request of a client
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a token bucket rate limiter for the given limit
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = limit.Requests
+	}
+	burst = max(burst, 1)
+
+	per := limit.Per
+	if per <= 0 {
+		per = time.Second
+	}
+
+	return &RateLimiter{
+		rate:   float64(limit.Requests) / per.Seconds(),
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// WithRateLimit throttles every request of a new Polygon Client.
+// The limiter is shared by all copies of the client, e.g. the ones made by UseV1Endpoints.
+func WithRateLimit(limit RateLimit) ClientOption {
+	return func(client *Client) {
+		client.rateLimiter = NewRateLimiter(limit)
+	}
+}
+
+// WithRateLimiter sets an existing rate limiter for a new Polygon Client, it allows several clients to share a budget
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(client *Client) {
+		client.rateLimiter = limiter
+	}
+}
+
+// Wait blocks until a request can be made or ctx is done
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// reserve a token, the bucket may go negative which queues callers in order
+	l.mu.Lock()
+	now := time.Now()
+	l.refill(now)
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		if l.rate <= 0 {
+			l.tokens++
+			l.mu.Unlock()
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if err := sleepContext(ctx, wait); err != nil {
+		// give the reservation back
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	l.tokens = min(l.burst, l.tokens+elapsed*l.rate)
+	l.last = now
+}
@@ -0,0 +1,47 @@
+package polygon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{Requests: 20, Per: time.Second, Burst: 1})
+
+	start := time.Now()
+	for range 3 {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestRateLimiterContextCancelled(t *testing.T) {
+	limiter := NewRateLimiter(RateLimitBasic)
+	for range 5 {
+		assert.NoError(t, limiter.Wait(context.Background()))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRateLimitSharedAcrossEndpointVersions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	client := NewClient("test-token", WithBaseURL(srv.URL+"/v2"), WithRateLimit(RateLimit{Requests: 1, Per: time.Hour}))
+	assert.NoError(t, client.GetJSON(context.Background(), "/first", &struct{}{}))
+
+	v1 := client.UseV1Endpoints()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, v1.GetJSON(ctx, "/second", &struct{}{}), context.DeadlineExceeded)
+}
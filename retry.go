@@ -0,0 +1,164 @@
+package polygon
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how failed requests are retried.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one. Values below 1 mean a single attempt.
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry, doubled on every following retry
+	BaseBackoff time.Duration
+	// MaxBackoff caps the computed backoff. It does not cap delays requested through Retry-After.
+	MaxBackoff time.Duration
+	// Jitter is the fraction (0 to 1) of the backoff that is randomized
+	Jitter float64
+	// RetryableStatusCodes lists the HTTP status codes that can be retried
+	RetryableStatusCodes []int
+	// RetryableError reports whether a transport error can be retried. Defaults to IsRetryableNetworkError.
+	RetryableError func(error) bool
+	// OnAttempt is called after every attempt, it can be used for logging
+	OnAttempt func(RetryAttempt)
+}
+
+// RetryAttempt describes the outcome of a single attempt
+type RetryAttempt struct {
+	// Attempt is the 1-based attempt number
+	Attempt int
+	// URL is the requested URL
+	URL string
+	// StatusCode is the HTTP status code, zero if no response was received
+	StatusCode int
+	// Err is the error of the attempt, nil on success
+	Err error
+	// Backoff is the delay before the next attempt, zero if no retry will be made
+	Backoff time.Duration
+}
+
+// DefaultRetryPolicy returns a retry policy suitable for most batch jobs:
+// 5 attempts, exponential backoff from 500ms up to 30s, retrying 429, 502, 503 and 504.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 5,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryableError: IsRetryableNetworkError,
+	}
+}
+
+// WithRetryPolicy sets the retry policy for a new Polygon Client
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(client *Client) {
+		client.retryPolicy = &policy
+	}
+}
+
+// IsRetryableNetworkError reports whether err is a transient network error such as
+// a connection reset, a refused connection, an unexpected EOF or a timeout.
+func IsRetryableNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func (p RetryPolicy) attempts() int {
+	if p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p RetryPolicy) retryableStatus(code int) bool {
+	return slices.Contains(p.RetryableStatusCodes, code)
+}
+
+func (p RetryPolicy) retryableError(err error) bool {
+	if p.RetryableError == nil {
+		return IsRetryableNetworkError(err)
+	}
+	return p.RetryableError(err)
+}
+
+// backoff returns the delay before the given retry (1-based)
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	d := p.BaseBackoff
+	for i := 1; i < retry && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 && d > 0 {
+		jitter := min(p.Jitter, 1)
+		d -= time.Duration(rand.Float64() * jitter * float64(d))
+	}
+
+	return d
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0), true
+	}
+
+	return 0, false
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
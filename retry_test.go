@@ -0,0 +1,97 @@
+package polygon
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy(attempts *[]RetryAttempt) RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	policy.OnAttempt = func(a RetryAttempt) {
+		*attempts = append(*attempts, a)
+	}
+	return policy
+}
+
+func TestRetryTransientStatus(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("1.5"))
+	}))
+	defer srv.Close()
+
+	var attempts []RetryAttempt
+	client := NewClient("test-token", WithBaseURL(srv.URL), WithRetryPolicy(testRetryPolicy(&attempts)))
+	f, err := client.GetFloat64(context.Background(), "/value")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, f)
+	assert.Len(t, attempts, 3)
+	assert.Equal(t, http.StatusServiceUnavailable, attempts[0].StatusCode)
+	assert.Error(t, attempts[0].Err)
+	assert.NoError(t, attempts[2].Err)
+	assert.Zero(t, attempts[2].Backoff)
+}
+
+func TestRetryNonRetryableStatus(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	var attempts []RetryAttempt
+	client := NewClient("test-token", WithBaseURL(srv.URL), WithRetryPolicy(testRetryPolicy(&attempts)))
+	_, err := client.GetBytes(context.Background(), "/value")
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte("{}"))
+	}))
+	defer srv.Close()
+
+	var attempts []RetryAttempt
+	client := NewClient("test-token", WithBaseURL(srv.URL), WithRetryPolicy(testRetryPolicy(&attempts)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := client.GetBytes(ctx, "/value")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, time.Second, attempts[0].Backoff)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	d, ok := parseRetryAfter("7", now)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, d)
+
+	d, ok = parseRetryAfter(now.Add(3*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, d)
+
+	_, ok = parseRetryAfter("soon", now)
+	assert.False(t, ok)
+}
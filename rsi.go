@@ -2,14 +2,14 @@ package polygon
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 	"strings"
 )
 
 var (
-	ErrRSIStatus    = errors.New("rsi: unexpected status")
-	ErrRSINoResults = errors.New("rsi: no results")
+	ErrRSIStatus    = fmt.Errorf("rsi: %w", ErrUnexpectedStatus)
+	ErrRSINoResults = fmt.Errorf("rsi: %w", ErrNoResults)
 )
 
 // RSIOption options for fetching RSI
@@ -32,19 +32,25 @@ type RSIResponse struct {
 		Underlying struct {
 			URL string `json:"url"`
 		} `json:"underlying"`
-		Values []struct {
-			Timestamp int64   `json:"timestamp"`
-			Value     float64 `json:"value"`
-		} `json:"values"`
+		Values []IndicatorValue `json:"values"`
 	} `json:"results"`
 	Status    string `json:"status"`
 	RequestID string `json:"request_id"`
 	NextURL   string `json:"next_url"`
 }
 
+// PageItems implements Page
+func (r RSIResponse) PageItems() []IndicatorValue {
+	return r.Results.Values
+}
+
+// NextPageURL implements Page
+func (r RSIResponse) NextPageURL() string {
+	return r.NextURL
+}
+
 // LatestRelativeStrengthIndex get latest stock RSI by day for a given ticker
 func (c Client) LatestRelativeStrengthIndex(ctx context.Context, ticker string) (float64, error) {
-	c = c.UseV1Endpoints()
 	ticker = strings.ToUpper(strings.TrimSpace(ticker))
 	opt := &RSIOption{
 		Timespan: Day,
@@ -68,14 +74,13 @@ func (c Client) LatestRelativeStrengthIndex(ctx context.Context, ticker string)
 
 // RelativeStrengthIndex get stock RSI for a given ticker
 func (c Client) RelativeStrengthIndex(ctx context.Context, ticker string, opt *RSIOption) (resp RSIResponse, err error) {
-	c = c.UseV1Endpoints()
 	ticker = strings.ToUpper(strings.TrimSpace(ticker))
-	endpoint, err := c.endpointWithOpts("/indicators/rsi/"+ticker, opt)
+	endpoint, err := c.endpointWithOpts("/v1/indicators/rsi/"+ticker, opt)
 	if err != nil {
 		return
 	}
 
-	if err = c.GetJSON(ctx, endpoint, &resp); err != nil {
+	if err = c.fetchJSON(ctx, "RelativeStrengthIndex", endpoint, &resp); err != nil {
 		err = fmt.Errorf("get json: %w", err)
 		return
 	}
@@ -92,3 +97,10 @@ func (c Client) RelativeStrengthIndex(ctx context.Context, ticker string, opt *R
 
 	return resp, err
 }
+
+// RelativeStrengthIndexIter iterates over all RSI values for a given ticker, following next_url across pages
+func (c Client) RelativeStrengthIndexIter(ctx context.Context, ticker string, opt *RSIOption, opts ...PaginationOption) iter.Seq2[IndicatorValue, error] {
+	return Paginate(WithEndpointName(ctx, "RelativeStrengthIndex"), &c, func(ctx context.Context) (RSIResponse, error) {
+		return c.RelativeStrengthIndex(ctx, ticker, opt)
+	}, opts...)
+}
@@ -2,14 +2,14 @@ package polygon
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"iter"
 	"strings"
 )
 
 var (
-	ErrSMAStatus    = errors.New("sma: unexpected status")
-	ErrSMANoResults = errors.New("sma: no results")
+	ErrSMAStatus    = fmt.Errorf("sma: %w", ErrUnexpectedStatus)
+	ErrSMANoResults = fmt.Errorf("sma: %w", ErrNoResults)
 )
 
 // SMAOption options for fetching SMA
@@ -32,26 +32,32 @@ type SMAResponse struct {
 		Underlying struct {
 			URL string `json:"url"`
 		} `json:"underlying"`
-		Values []struct {
-			Timestamp int64   `json:"timestamp"`
-			Value     float64 `json:"value"`
-		} `json:"values"`
+		Values []IndicatorValue `json:"values"`
 	} `json:"results"`
 	Status    string `json:"status"`
 	RequestID string `json:"request_id"`
 	NextURL   string `json:"next_url"`
 }
 
+// PageItems implements Page
+func (r SMAResponse) PageItems() []IndicatorValue {
+	return r.Results.Values
+}
+
+// NextPageURL implements Page
+func (r SMAResponse) NextPageURL() string {
+	return r.NextURL
+}
+
 // SimpleMovingAverage get stock SMA for a given ticker
 func (c Client) SimpleMovingAverage(ctx context.Context, ticker string, opt *SMAOption) (resp SMAResponse, err error) {
-	c = c.UseV1Endpoints()
 	ticker = strings.ToUpper(strings.TrimSpace(ticker))
-	endpoint, err := c.endpointWithOpts("/indicators/sma/"+ticker, opt)
+	endpoint, err := c.endpointWithOpts("/v1/indicators/sma/"+ticker, opt)
 	if err != nil {
 		return
 	}
 
-	if err = c.GetJSON(ctx, endpoint, &resp); err != nil {
+	if err = c.fetchJSON(ctx, "SimpleMovingAverage", endpoint, &resp); err != nil {
 		err = fmt.Errorf("get json: %w", err)
 		return
 	}
@@ -68,3 +74,10 @@ func (c Client) SimpleMovingAverage(ctx context.Context, ticker string, opt *SMA
 
 	return resp, err
 }
+
+// SimpleMovingAverageIter iterates over all SMA values for a given ticker, following next_url across pages
+func (c Client) SimpleMovingAverageIter(ctx context.Context, ticker string, opt *SMAOption, opts ...PaginationOption) iter.Seq2[IndicatorValue, error] {
+	return Paginate(WithEndpointName(ctx, "SimpleMovingAverage"), &c, func(ctx context.Context) (SMAResponse, error) {
+		return c.SimpleMovingAverage(ctx, ticker, opt)
+	}, opts...)
+}
@@ -2,6 +2,7 @@ package polygon
 
 import (
 	"context"
+	"iter"
 )
 
 // StockSplits Get a list of historical stock splits
@@ -19,6 +20,16 @@ type StockSplitsResult struct {
 	Ticker        string  `json:"ticker"`
 }
 
+// PageItems implements Page
+func (s StockSplits) PageItems() []StockSplitsResult {
+	return s.Results
+}
+
+// NextPageURL implements Page
+func (s StockSplits) NextPageURL() string {
+	return s.NextURL
+}
+
 type StockSplitsOption struct {
 	Ticker           string `url:"ticker,omitempty"`
 	ExecutionDate    string `url:"execution_date,omitempty"`
@@ -30,17 +41,23 @@ type StockSplitsOption struct {
 
 // StockSplits Get a list of historical stock splits
 func (c Client) StockSplits(ctx context.Context, opt *StockSplitsOption) (StockSplits, error) {
-	c = c.UseV3Endpoints()
 	d := StockSplits{}
 
 	if opt == nil {
 		opt = new(StockSplitsOption)
 	}
 
-	endpoint, err := c.endpointWithOpts("/reference/splits", opt)
+	endpoint, err := c.endpointWithOpts("/v3/reference/splits", opt)
 	if err != nil {
 		return d, err
 	}
-	err = c.GetJSON(ctx, endpoint, &d)
+	err = c.fetchJSON(ctx, "StockSplits", endpoint, &d)
 	return d, err
 }
+
+// StockSplitsIter iterates over all stock splits, following next_url across pages
+func (c Client) StockSplitsIter(ctx context.Context, opt *StockSplitsOption, opts ...PaginationOption) iter.Seq2[StockSplitsResult, error] {
+	return Paginate(WithEndpointName(ctx, "StockSplits"), &c, func(ctx context.Context) (StockSplits, error) {
+		return c.StockSplits(ctx, opt)
+	}, opts...)
+}
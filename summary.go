@@ -84,7 +84,6 @@ func (sa SummaryAsset) resolveTicker() string {
 // Summary Get everything needed to visualize the tick-by-tick movement of a list of tickers.
 func (c Client) Summary(ctx context.Context, assets []SummaryAsset) (Summary, error) {
 	s := Summary{}
-	c = c.UseV1Endpoints()
 
 	opt := SummaryOption{}
 	for _, asset := range assets {
@@ -96,11 +95,11 @@ func (c Client) Summary(ctx context.Context, assets []SummaryAsset) (Summary, er
 		opt.TickerAnyOf += fmt.Sprintf(",%s", asset.resolveTicker())
 	}
 
-	endpoint, err := c.endpointWithOpts("/summaries", opt)
+	endpoint, err := c.endpointWithOpts("/v1/summaries", opt)
 	if err != nil {
 		return s, err
 	}
 
-	err = c.GetJSON(ctx, endpoint, &s)
+	err = c.fetchJSON(ctx, "Summary", endpoint, &s)
 	return s, err
 }
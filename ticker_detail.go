@@ -53,13 +53,12 @@ type TickerDetailOption struct {
 }
 
 func (c Client) TickerDetail(ctx context.Context, ticker string, opt *TickerDetailOption) (TickerDetail, error) {
-	c = c.UseV3Endpoints()
 	d := TickerDetail{}
 
-	endpoint, err := c.endpointWithOpts(fmt.Sprintf("/reference/tickers/%s", ticker), opt)
+	endpoint, err := c.endpointWithOpts(fmt.Sprintf("/v3/reference/tickers/%s", ticker), opt)
 	if err != nil {
 		return d, err
 	}
-	err = c.GetJSON(ctx, endpoint, &d)
+	err = c.fetchJSON(ctx, "TickerDetail", endpoint, &d)
 	return d, err
 }
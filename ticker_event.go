@@ -37,13 +37,12 @@ type TickerEventOption struct {
 
 // TickerEvent Get a timeline of events for the entity associated with the given ticker, CUSIP, or Composite FIGI.
 func (c Client) TickerEvent(ctx context.Context, ticker string, opt *TickerEventOption) (TickerEvent, error) {
-	c = c.UseVXEndpoints()
 	e := TickerEvent{}
 
-	endpoint, err := c.endpointWithOpts(fmt.Sprintf("/reference/tickers/%s/events", ticker), opt)
+	endpoint, err := c.endpointWithOpts(fmt.Sprintf("/vX/reference/tickers/%s/events", ticker), opt)
 	if err != nil {
 		return e, err
 	}
-	err = c.GetJSON(ctx, endpoint, &e)
+	err = c.fetchJSON(ctx, "TickerEvent", endpoint, &e)
 	return e, err
 }
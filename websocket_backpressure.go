@@ -0,0 +1,193 @@
+package polygon
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// BackpressurePolicy what a dispatcher does with new events when its delivery buffer is full
+type BackpressurePolicy int
+
+const (
+	// BackpressureBlock waits for room in the buffer, which stalls the stream read loop
+	BackpressureBlock BackpressurePolicy = iota
+	// BackpressureDropOldest drops the oldest buffered event with a symbol to make room
+	BackpressureDropOldest
+	// BackpressureDropNewest drops the new event
+	BackpressureDropNewest
+	// BackpressureCoalesceLatest replaces the buffered event of the same type and symbol with the new one,
+	// the oldest event is dropped when the buffer is full of other symbols
+	BackpressureCoalesceLatest
+)
+
+// String implements fmt.Stringer
+func (p BackpressurePolicy) String() string {
+	switch p {
+	case BackpressureBlock:
+		return "block"
+	case BackpressureDropOldest:
+		return "drop-oldest"
+	case BackpressureDropNewest:
+		return "drop-newest"
+	case BackpressureCoalesceLatest:
+		return "coalesce-latest"
+	}
+	return fmt.Sprintf("BackpressurePolicy(%d)", int(p))
+}
+
+// DeliveryStats counters of a dispatcher delivery buffer, per symbol
+type DeliveryStats struct {
+	Dropped   map[string]uint64
+	Coalesced map[string]uint64
+}
+
+// DispatcherOption applies an option to a Dispatcher.
+type DispatcherOption func(*Dispatcher)
+
+// WithBackpressure decouples handlers from the stream read loop through a buffer of size events,
+// delivered in order by a dedicated goroutine, and applies policy once the buffer is full.
+// The dispatcher must then be closed with Close.
+func WithBackpressure(size int, policy BackpressurePolicy) DispatcherOption {
+	return func(d *Dispatcher) {
+		d.queue = newEventQueue(size, policy)
+	}
+}
+
+// eventQueue bounded FIFO of events
+type eventQueue struct {
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	size     int
+	policy   BackpressurePolicy
+	items    []*queuedEvent
+	pending  map[string]*queuedEvent // coalescing key -> buffered event
+	closed   bool
+
+	dropped   map[string]uint64
+	coalesced map[string]uint64
+}
+
+type queuedEvent struct {
+	key   string
+	event Event
+}
+
+func newEventQueue(size int, policy BackpressurePolicy) *eventQueue {
+	q := &eventQueue{
+		size:      max(size, 1),
+		policy:    policy,
+		pending:   make(map[string]*queuedEvent),
+		dropped:   make(map[string]uint64),
+		coalesced: make(map[string]uint64),
+	}
+	q.notEmpty = sync.NewCond(&q.mu)
+	q.notFull = sync.NewCond(&q.mu)
+	return q
+}
+
+// push buffers an event according to the policy, events pushed after close are discarded
+func (q *eventQueue) push(e Event) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// events without a symbol, such as status messages, are never dropped nor coalesced,
+	// they are buffered even when the buffer is full
+	if e.EventSymbol() == "" {
+		if !q.closed {
+			q.items = append(q.items, &queuedEvent{event: e})
+			q.notEmpty.Signal()
+		}
+		return
+	}
+
+	var key string
+	if q.policy == BackpressureCoalesceLatest {
+		key = e.EventType() + "." + e.EventSymbol()
+		if queued, ok := q.pending[key]; ok {
+			queued.event = e
+			q.coalesced[e.EventSymbol()]++
+			return
+		}
+	}
+
+full:
+	for len(q.items) >= q.size && !q.closed {
+		switch q.policy {
+		case BackpressureBlock:
+			q.notFull.Wait()
+		case BackpressureDropNewest:
+			q.dropped[e.EventSymbol()]++
+			return
+		default:
+			i := slices.IndexFunc(q.items, func(queued *queuedEvent) bool { return queued.event.EventSymbol() != "" })
+			if i < 0 {
+				// the buffer is full of events that are never dropped
+				break full
+			}
+			oldest := q.remove(i)
+			q.dropped[oldest.EventSymbol()]++
+		}
+	}
+	if q.closed {
+		return
+	}
+
+	queued := &queuedEvent{key: key, event: e}
+	q.items = append(q.items, queued)
+	if key != "" {
+		q.pending[key] = queued
+	}
+	q.notEmpty.Signal()
+}
+
+// pop waits for an event, it returns false once the queue is closed and drained
+func (q *eventQueue) pop() (Event, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.items) == 0 && !q.closed {
+		q.notEmpty.Wait()
+	}
+	if len(q.items) == 0 {
+		return nil, false
+	}
+
+	e := q.remove(0)
+	q.notFull.Signal()
+	return e, true
+}
+
+// remove removes the i-th oldest event, it must be called with mu held
+func (q *eventQueue) remove(i int) Event {
+	queued := q.items[i]
+	if i == 0 {
+		q.items[0] = nil
+		q.items = q.items[1:]
+	} else {
+		q.items = slices.Delete(q.items, i, i+1)
+	}
+	if queued.key != "" && q.pending[queued.key] == queued {
+		delete(q.pending, queued.key)
+	}
+	return queued.event
+}
+
+func (q *eventQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	q.notEmpty.Broadcast()
+	q.notFull.Broadcast()
+}
+
+func (q *eventQueue) stats() DeliveryStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return DeliveryStats{
+		Dropped:   maps.Clone(q.dropped),
+		Coalesced: maps.Clone(q.coalesced),
+	}
+}
@@ -0,0 +1,152 @@
+package polygon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// stalledDispatcher returns a dispatcher whose AM handler is blocked until release is closed,
+// after the first event has been taken out of the buffer
+func stalledDispatcher(t *testing.T, policy BackpressurePolicy) (d *Dispatcher, got *[]StockAggregate, release chan struct{}) {
+	d = NewDispatcher(ClusterStocks, WithBackpressure(2, policy))
+	got = &[]StockAggregate{}
+	release = make(chan struct{})
+	started := make(chan struct{})
+	On(d, func(a StockAggregate) {
+		if len(*got) == 0 {
+			close(started)
+			<-release
+		}
+		*got = append(*got, a)
+	})
+
+	d.HandleFrame([]byte(`{"ev":"AM","sym":"AAPL","c":1}`))
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("delivery did not start")
+	}
+	return d, got, release
+}
+
+func closes(got []StockAggregate) []float64 {
+	var c []float64
+	for _, a := range got {
+		c = append(c, a.TickClose)
+	}
+	return c
+}
+
+func TestBackpressureDropNewest(t *testing.T) {
+	d, got, release := stalledDispatcher(t, BackpressureDropNewest)
+	d.HandleFrame([]byte(`[{"ev":"AM","sym":"AAPL","c":2},{"ev":"AM","sym":"MSFT","c":3},{"ev":"AM","sym":"AAPL","c":4},{"ev":"AM","sym":"AAPL","c":5}]`))
+	close(release)
+	d.Close()
+
+	assert.Equal(t, []float64{1, 2, 3}, closes(*got))
+	assert.Equal(t, map[string]uint64{"AAPL": 2}, d.DeliveryStats().Dropped)
+}
+
+func TestBackpressureDropOldest(t *testing.T) {
+	d, got, release := stalledDispatcher(t, BackpressureDropOldest)
+	d.HandleFrame([]byte(`[{"ev":"AM","sym":"AAPL","c":2},{"ev":"AM","sym":"MSFT","c":3},{"ev":"AM","sym":"AAPL","c":4},{"ev":"AM","sym":"AAPL","c":5}]`))
+	close(release)
+	d.Close()
+
+	assert.Equal(t, []float64{1, 4, 5}, closes(*got))
+	assert.Equal(t, map[string]uint64{"AAPL": 1, "MSFT": 1}, d.DeliveryStats().Dropped)
+}
+
+func TestBackpressureCoalesceLatest(t *testing.T) {
+	d, got, release := stalledDispatcher(t, BackpressureCoalesceLatest)
+	d.HandleFrame([]byte(`[{"ev":"AM","sym":"AAPL","c":2},{"ev":"AM","sym":"MSFT","c":3},{"ev":"AM","sym":"AAPL","c":4},{"ev":"AM","sym":"AAPL","c":5},` +
+		`{"ev":"AM","sym":"GOOG","c":6}]`))
+	close(release)
+	d.Close()
+
+	// AAPL is coalesced in place, then dropped as the oldest to make room for GOOG
+	assert.Equal(t, []float64{1, 3, 6}, closes(*got))
+	stats := d.DeliveryStats()
+	assert.Equal(t, map[string]uint64{"AAPL": 2}, stats.Coalesced)
+	assert.Equal(t, map[string]uint64{"AAPL": 1}, stats.Dropped)
+}
+
+func TestBackpressureBlock(t *testing.T) {
+	d, got, release := stalledDispatcher(t, BackpressureBlock)
+
+	dispatched := make(chan struct{})
+	go func() {
+		d.HandleFrame([]byte(`[{"ev":"AM","sym":"AAPL","c":2},{"ev":"AM","sym":"MSFT","c":3},{"ev":"AM","sym":"AAPL","c":4}]`))
+		close(dispatched)
+	}()
+
+	select {
+	case <-dispatched:
+		t.Fatal("dispatch did not block on a full buffer")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-dispatched
+	d.Close()
+
+	assert.Equal(t, []float64{1, 2, 3, 4}, closes(*got))
+	assert.Empty(t, d.DeliveryStats().Dropped)
+}
+
+func TestBackpressureKeepsStatusMessages(t *testing.T) {
+	for _, policy := range []BackpressurePolicy{BackpressureDropNewest, BackpressureDropOldest, BackpressureCoalesceLatest} {
+		d, got, release := stalledDispatcher(t, policy)
+		statuses := EventChan[StatusMessage](d, 10)
+		d.HandleFrame([]byte(`[{"ev":"AM","sym":"AAPL","c":2},{"ev":"AM","sym":"MSFT","c":3},` +
+			`{"ev":"status","status":"success","message":"subscribed to: AM.NVDA"},{"ev":"status","status":"success","message":"subscribed to: AM.TSLA"}]`))
+		close(release)
+		d.Close()
+
+		assert.Equal(t, []float64{1, 2, 3}, closes(*got), policy)
+		assert.Len(t, statuses, 2, policy)
+		stats := d.DeliveryStats()
+		assert.Empty(t, stats.Dropped, policy)
+		assert.Empty(t, stats.Coalesced, policy)
+	}
+}
+
+func TestBackpressureDropOldestSkipsStatusMessages(t *testing.T) {
+	d, got, release := stalledDispatcher(t, BackpressureDropOldest)
+	statuses := EventChan[StatusMessage](d, 10)
+	d.HandleFrame([]byte(`[{"ev":"status","status":"success","message":"subscribed to: AM.NVDA"},{"ev":"AM","sym":"AAPL","c":2},` +
+		`{"ev":"status","status":"success","message":"subscribed to: AM.TSLA"},{"ev":"AM","sym":"MSFT","c":3}]`))
+	close(release)
+	d.Close()
+
+	// AAPL is the oldest event that can be dropped
+	assert.Equal(t, []float64{1, 3}, closes(*got))
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, map[string]uint64{"AAPL": 1}, d.DeliveryStats().Dropped)
+}
+
+func TestBackpressureClose(t *testing.T) {
+	d := NewDispatcher(ClusterStocks, WithBackpressure(10, BackpressureBlock))
+	var got []StockAggregate
+	On(d, func(a StockAggregate) { got = append(got, a) })
+
+	d.HandleFrame([]byte(`{"ev":"AM","sym":"AAPL","c":1}`))
+	d.Close()
+	// events dispatched after Close are discarded
+	d.HandleFrame([]byte(`{"ev":"AM","sym":"AAPL","c":2}`))
+	d.HandleFrame([]byte(`{"ev":"status","status":"success","message":"subscribed to: AM.AAPL"}`))
+	assert.Equal(t, []float64{1}, closes(got))
+
+	// without backpressure Close is a no-op
+	NewDispatcher(ClusterStocks).Close()
+}
+
+func TestBackpressurePolicyString(t *testing.T) {
+	assert.Equal(t, "block", BackpressureBlock.String())
+	assert.Equal(t, "drop-oldest", BackpressureDropOldest.String())
+	assert.Equal(t, "drop-newest", BackpressureDropNewest.String())
+	assert.Equal(t, "coalesce-latest", BackpressureCoalesceLatest.String())
+	assert.Equal(t, "BackpressurePolicy(9)", BackpressurePolicy(9).String())
+}
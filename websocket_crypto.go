@@ -1,9 +1,6 @@
 package polygon
 
-import (
-	"fmt"
-	"strings"
-)
+import "fmt"
 
 // CryptoEventTypeEnum event type enum
 type CryptoEventTypeEnum string
@@ -15,9 +12,16 @@ const (
 	CryptoEventTypeXA CryptoEventTypeEnum = "XA"
 	// CryptoEventTypeXAS second aggregates
 	CryptoEventTypeXAS CryptoEventTypeEnum = "XAS"
+	// CryptoEventTypeXT trades
+	CryptoEventTypeXT CryptoEventTypeEnum = "XT"
+	// CryptoEventTypeXQ quotes
+	CryptoEventTypeXQ CryptoEventTypeEnum = "XQ"
+	// CryptoEventTypeXL2 level 2 book
+	CryptoEventTypeXL2 CryptoEventTypeEnum = "XL2"
 )
 
 type CryptoAggregate struct {
+	FeedTag
 	Event                 CryptoEventTypeEnum `json:"ev"`   // The event type.
 	Pair                  string              `json:"pair"` // The crypto pair.
 	TickOpen              float64             `json:"o"`    // The opening tick price for this aggregate window.
@@ -33,32 +37,95 @@ type CryptoAggregate struct {
 	PerformancePercentage float64             // performance percentage from last market close
 }
 
-func (c Client) SubscribeCryptoAggregates(client WebSocketClient, pairs []string, eventType CryptoEventTypeEnum) (err error) {
-	// connect
-	client.Dial(fmt.Sprintf("%s/crypto", c.websocketBaseURL), nil)
-	// auth
-	err = client.WriteMessage(TextMessage, []byte(fmt.Sprintf("{\"action\":\"auth\",\"params\":\"%s\"}", c.token)))
-	if err != nil {
-		return
-	}
+// EventType implements Event
+func (a CryptoAggregate) EventType() string { return string(a.Event) }
 
-	channel := resolveCryptoChannel(pairs, eventType)
-	err = client.WriteMessage(TextMessage, []byte(fmt.Sprintf("{\"action\":\"subscribe\",\"params\":\"%s\"}", channel)))
-	if err != nil {
-		return
-	}
+// EventSymbol implements Event
+func (a CryptoAggregate) EventSymbol() string { return a.Pair }
 
-	return
+// CryptoTrade tick-level trade of a crypto pair
+type CryptoTrade struct {
+	FeedTag
+	Event             CryptoEventTypeEnum `json:"ev"`   // The event type.
+	Pair              string              `json:"pair"` // The crypto pair.
+	Price             float64             `json:"p"`    // The price.
+	Size              float64             `json:"s"`    // The size.
+	Conditions        []int               `json:"c"`    // The conditions. 0 (or empty array): empty 1: sellside 2: buyside
+	ID                string              `json:"i"`    // The ID of the trade (optional).
+	Exchange          int                 `json:"x"`    // The crypto exchange ID.
+	Timestamp         int64               `json:"t"`    // The timestamp in Unix Milliseconds.
+	ReceivedTimestamp int64               `json:"r"`    // The timestamp that the tick was received by Polygon.
 }
 
-func resolveCryptoChannel(pairs []string, eventType CryptoEventTypeEnum) string {
-	var sb strings.Builder
-	for i, pair := range pairs {
-		sb.WriteString(fmt.Sprintf("%s.%s", eventType, pair))
-		if i < len(pairs)-1 {
-			sb.WriteString(",")
-		}
-	}
+// EventType implements Event
+func (t CryptoTrade) EventType() string { return string(t.Event) }
+
+// EventSymbol implements Event
+func (t CryptoTrade) EventSymbol() string { return t.Pair }
+
+// CryptoQuote BBO quote of a crypto pair
+type CryptoQuote struct {
+	FeedTag
+	Event             CryptoEventTypeEnum `json:"ev"`   // The event type.
+	Pair              string              `json:"pair"` // The crypto pair.
+	BidPrice          float64             `json:"bp"`   // The bid price.
+	BidSize           float64             `json:"bs"`   // The bid size.
+	AskPrice          float64             `json:"ap"`   // The ask price.
+	AskSize           float64             `json:"as"`   // The ask size.
+	Exchange          int                 `json:"x"`    // The crypto exchange ID.
+	Timestamp         int64               `json:"t"`    // The timestamp in Unix Milliseconds.
+	ReceivedTimestamp int64               `json:"r"`    // The timestamp that the tick was received by Polygon.
+}
+
+// EventType implements Event
+func (q CryptoQuote) EventType() string { return string(q.Event) }
+
+// EventSymbol implements Event
+func (q CryptoQuote) EventSymbol() string { return q.Pair }
+
+// CryptoLevel2 level 2 book update of a crypto pair
+type CryptoLevel2 struct {
+	FeedTag
+	Event             CryptoEventTypeEnum `json:"ev"`   // The event type.
+	Pair              string              `json:"pair"` // The crypto pair.
+	Bids              []PriceLevel        `json:"b"`    // The bid prices and sizes, a zero size removes the level.
+	Asks              []PriceLevel        `json:"a"`    // The ask prices and sizes, a zero size removes the level.
+	Exchange          int                 `json:"x"`    // The crypto exchange ID.
+	Timestamp         int64               `json:"t"`    // The timestamp in Unix Milliseconds.
+	ReceivedTimestamp int64               `json:"r"`    // The timestamp that the tick was received by Polygon.
+}
 
-	return sb.String()
+// EventType implements Event
+func (l CryptoLevel2) EventType() string { return string(l.Event) }
+
+// EventSymbol implements Event
+func (l CryptoLevel2) EventSymbol() string { return l.Pair }
+
+// SubscribeCryptoTrades subscribes to the trades (XT) of the given pairs
+func (c Client) SubscribeCryptoTrades(client WebSocketClient, pairs []string) error {
+	return c.SubscribeCryptoAggregates(client, pairs, CryptoEventTypeXT)
+}
+
+// SubscribeCryptoQuotes subscribes to the quotes (XQ) of the given pairs
+func (c Client) SubscribeCryptoQuotes(client WebSocketClient, pairs []string) error {
+	return c.SubscribeCryptoAggregates(client, pairs, CryptoEventTypeXQ)
+}
+
+// SubscribeCryptoLevel2 subscribes to the level 2 book (XL2) of the given pairs
+func (c Client) SubscribeCryptoLevel2(client WebSocketClient, pairs []string) error {
+	return c.SubscribeCryptoAggregates(client, pairs, CryptoEventTypeXL2)
+}
+
+// SubscribeCryptoAggregates subscribes to the given event type of the given pairs on the crypto cluster
+func (c Client) SubscribeCryptoAggregates(client WebSocketClient, pairs []string, eventType CryptoEventTypeEnum) error {
+	return c.subscribe(client, ClusterCrypto, CryptoChannels(eventType, pairs...))
+}
+
+// CryptoChannels returns the channels of the given event type for every pair, e.g. "XA.BTC-USD"
+func CryptoChannels(eventType CryptoEventTypeEnum, pairs ...string) []string {
+	channels := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		channels = append(channels, fmt.Sprintf("%s.%s", eventType, pair))
+	}
+	return channels
 }
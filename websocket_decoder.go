@@ -0,0 +1,321 @@
+package polygon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Event a decoded websocket event
+type Event interface {
+	// EventType returns the polygon event type (the "ev" field), e.g. "AM" or "status"
+	EventType() string
+	// EventSymbol returns the symbol or pair the event relates to, empty if none
+	EventSymbol() string
+	// EventFeed returns the feed the event came from, empty if unknown
+	EventFeed() Feed
+}
+
+// StatusMessage status event sent by polygon for connection, authentication and subscription changes
+type StatusMessage struct {
+	FeedTag
+	Event   string `json:"ev"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// EventType implements Event
+func (m StatusMessage) EventType() string { return m.Event }
+
+// EventSymbol implements Event
+func (m StatusMessage) EventSymbol() string { return "" }
+
+// RawEvent event of a type the decoder does not know, passed through as raw JSON
+type RawEvent struct {
+	FeedTag
+	Type   string
+	Symbol string
+	Data   json.RawMessage
+}
+
+// EventType implements Event
+func (e RawEvent) EventType() string { return e.Type }
+
+// EventSymbol implements Event
+func (e RawEvent) EventSymbol() string { return e.Symbol }
+
+type eventDecodeFunc func(data json.RawMessage, feed Feed) (Event, error)
+
+// eventDecoders routes events per cluster, as the same event type can mean different things on different clusters
+var eventDecoders = map[Cluster]map[string]eventDecodeFunc{
+	ClusterStocks: {
+		string(StockEventTypeAM):   decodeEvent[StockAggregate],
+		string(StockEventTypeA):    decodeEvent[StockAggregate],
+		string(StockEventTypeT):    decodeEvent[StockTrade],
+		string(StockEventTypeQ):    decodeEvent[StockQuote],
+		string(StockEventTypeLULD): decodeEvent[LimitUpLimitDown],
+		string(StockEventTypeFMV):  decodeEvent[FairMarketValue],
+	},
+	ClusterCrypto: {
+		string(CryptoEventTypeXA):  decodeEvent[CryptoAggregate],
+		string(CryptoEventTypeXAS): decodeEvent[CryptoAggregate],
+		string(CryptoEventTypeXT):  decodeEvent[CryptoTrade],
+		string(CryptoEventTypeXQ):  decodeEvent[CryptoQuote],
+		string(CryptoEventTypeXL2): decodeEvent[CryptoLevel2],
+	},
+	ClusterForex: {
+		string(ForexEventTypeCA):  decodeEvent[ForexAggregate],
+		string(ForexEventTypeCAS): decodeEvent[ForexAggregate],
+		string(ForexEventTypeC):   decodeEvent[ForexQuote],
+	},
+	ClusterOptions: {
+		string(OptionEventTypeAM): decodeEvent[OptionAggregate],
+		string(OptionEventTypeA):  decodeEvent[OptionAggregate],
+		string(OptionEventTypeT):  decodeEvent[OptionTrade],
+		string(OptionEventTypeQ):  decodeEvent[OptionQuote],
+	},
+	ClusterIndices: {
+		string(IndexEventTypeV):  decodeEvent[IndexValue],
+		string(IndexEventTypeAM): decodeEvent[IndexAggregate],
+		string(IndexEventTypeA):  decodeEvent[IndexAggregate],
+	},
+}
+
+func decodeEvent[T Event](data json.RawMessage, feed Feed) (Event, error) {
+	var e T
+	err := json.Unmarshal(data, &e)
+	if tag, ok := any(&e).(interface{ setFeed(Feed) }); ok {
+		tag.setFeed(feed)
+	}
+	return e, err
+}
+
+// Decoder splits websocket frames of a cluster into typed events
+type Decoder struct {
+	cluster Cluster
+	feed    Feed
+}
+
+// NewDecoder creates a decoder for the given cluster, events are not tagged with a feed
+func NewDecoder(cluster Cluster) *Decoder {
+	return &Decoder{cluster: cluster}
+}
+
+// NewFeedDecoder creates a decoder for the given cluster tagging every event with feed
+func NewFeedDecoder(feed Feed, cluster Cluster) *Decoder {
+	return &Decoder{cluster: cluster, feed: feed}
+}
+
+// Decode splits a frame, either a JSON array or a single object, into events.
+// Unknown event types are returned as RawEvent.
+func (d *Decoder) Decode(frame []byte) ([]Event, error) {
+	frame = bytes.TrimSpace(bytes.Trim(frame, "\x00"))
+
+	var items []json.RawMessage
+	if len(frame) > 0 && frame[0] == '{' {
+		items = []json.RawMessage{frame}
+	} else if err := json.Unmarshal(frame, &items); err != nil {
+		return nil, fmt.Errorf("decode frame: %w", err)
+	}
+
+	events := make([]Event, 0, len(items))
+	for _, item := range items {
+		e, err := d.decodeItem(item)
+		if err != nil {
+			return events, err
+		}
+		events = append(events, e)
+	}
+
+	return events, nil
+}
+
+func (d *Decoder) decodeItem(item json.RawMessage) (Event, error) {
+	var head struct {
+		Event  string `json:"ev"`
+		Symbol string `json:"sym"`
+		Pair   string `json:"pair"`
+	}
+	if err := json.Unmarshal(item, &head); err != nil {
+		return nil, fmt.Errorf("decode event: %w", err)
+	}
+
+	decode, ok := eventDecoders[d.cluster][head.Event]
+	if head.Event == "status" {
+		decode, ok = decodeEvent[StatusMessage], true
+	}
+
+	if !ok {
+		symbol := head.Symbol
+		if symbol == "" {
+			symbol = head.Pair
+		}
+		return RawEvent{FeedTag: FeedTag{Feed: d.feed}, Type: head.Event, Symbol: symbol, Data: item}, nil
+	}
+
+	e, err := decode(item, d.feed)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s event: %w", head.Event, err)
+	}
+	return e, nil
+}
+
+// Dispatcher decodes frames and delivers events to the handlers registered for their type
+type Dispatcher struct {
+	decoder  *Decoder
+	mu       sync.RWMutex
+	handlers map[reflect.Type][]func(Event)
+	fallback []func(Event)
+	onError  func(error)
+
+	queue     *eventQueue
+	startOnce sync.Once
+	done      chan struct{}
+}
+
+// NewDispatcher creates a dispatcher for the given cluster, events are not tagged with a feed
+// unless the dispatcher is attached to a Stream
+func NewDispatcher(cluster Cluster, opts ...DispatcherOption) *Dispatcher {
+	return NewFeedDispatcher("", cluster, opts...)
+}
+
+// NewFeedDispatcher creates a dispatcher for the given cluster tagging every event with feed
+func NewFeedDispatcher(feed Feed, cluster Cluster, opts ...DispatcherOption) *Dispatcher {
+	d := &Dispatcher{
+		decoder:  NewFeedDecoder(feed, cluster),
+		handlers: make(map[reflect.Type][]func(Event)),
+		done:     make(chan struct{}),
+	}
+	for _, applyOption := range opts {
+		applyOption(d)
+	}
+	return d
+}
+
+// On registers a handler called with every event of type T, e.g. On(d, func(a StockAggregate) {...})
+func On[T Event](d *Dispatcher, fn func(T)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	t := reflect.TypeFor[T]()
+	d.handlers[t] = append(d.handlers[t], func(e Event) { fn(e.(T)) })
+}
+
+// EventChan returns a channel receiving every event of type T.
+// Delivery blocks when the channel buffer is full, so the channel must be drained,
+// or the dispatcher created WithBackpressure to keep a slow reader from stalling the stream.
+func EventChan[T Event](d *Dispatcher, size int) <-chan T {
+	ch := make(chan T, size)
+	On(d, func(e T) { ch <- e })
+	return ch
+}
+
+// OnAny registers a handler called with every event, whatever its type
+func (d *Dispatcher) OnAny(fn func(Event)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.fallback = append(d.fallback, fn)
+}
+
+// OnError registers the handler of frames that cannot be decoded
+func (d *Dispatcher) OnError(fn func(error)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onError = fn
+}
+
+// Dispatch decodes a frame and delivers its events
+func (d *Dispatcher) Dispatch(frame []byte) error {
+	d.mu.RLock()
+	decoder := d.decoder
+	d.mu.RUnlock()
+
+	events, err := decoder.Decode(frame)
+	for _, e := range events {
+		if d.queue == nil {
+			d.deliver(e)
+			continue
+		}
+		d.startDelivery()
+		d.queue.push(e)
+	}
+	return err
+}
+
+// Close delivers the buffered events and stops the delivery goroutine of a dispatcher created WithBackpressure.
+// Events dispatched afterwards are discarded.
+func (d *Dispatcher) Close() {
+	if d.queue == nil {
+		return
+	}
+	d.startDelivery()
+	d.queue.close()
+	<-d.done
+}
+
+// DeliveryStats returns the dropped and coalesced counters per symbol, empty without backpressure
+func (d *Dispatcher) DeliveryStats() DeliveryStats {
+	if d.queue == nil {
+		return DeliveryStats{}
+	}
+	return d.queue.stats()
+}
+
+func (d *Dispatcher) startDelivery() {
+	d.startOnce.Do(func() {
+		go func() {
+			defer close(d.done)
+			for {
+				e, ok := d.queue.pop()
+				if !ok {
+					return
+				}
+				d.deliver(e)
+			}
+		}()
+	})
+}
+
+// HandleFrame dispatches a frame and reports decoding errors to the OnError handler.
+// It can be used as a Stream message handler.
+func (d *Dispatcher) HandleFrame(frame []byte) {
+	if err := d.Dispatch(frame); err != nil {
+		d.mu.RLock()
+		onError := d.onError
+		d.mu.RUnlock()
+
+		if onError != nil {
+			onError(err)
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(e Event) {
+	d.mu.RLock()
+	handlers := d.handlers[reflect.TypeOf(e)]
+	fallback := d.fallback
+	d.mu.RUnlock()
+
+	for _, fn := range handlers {
+		fn(e)
+	}
+	for _, fn := range fallback {
+		fn(e)
+	}
+}
+
+// WithDispatcher delivers the frames read by the stream to the given dispatcher.
+// A dispatcher without a feed, see NewFeedDispatcher, tags events with the feed of the stream;
+// it should not be shared by streams of different feeds.
+func WithDispatcher(d *Dispatcher) StreamOption {
+	return func(s *Stream) {
+		d.mu.Lock()
+		if d.decoder.feed == "" {
+			d.decoder = NewFeedDecoder(s.client.feed, d.decoder.cluster)
+		}
+		d.mu.Unlock()
+		s.onMessage = d.HandleFrame
+	}
+}
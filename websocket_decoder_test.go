@@ -0,0 +1,88 @@
+package polygon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoderSplitsFrame(t *testing.T) {
+	frame := []byte(`[{"ev":"status","status":"connected","message":"Connected Successfully"},` +
+		`{"ev":"AM","sym":"AAPL","v":100,"c":190.5,"s":1700000000000,"e":1700000060000},` +
+		`{"ev":"NOI","sym":"MSFT","x":4}]` + "\x00\x00")
+
+	events, err := NewDecoder(ClusterStocks).Decode(frame)
+	assert.NoError(t, err)
+	if !assert.Len(t, events, 3) {
+		return
+	}
+
+	assert.Equal(t, StatusMessage{Event: "status", Status: "connected", Message: "Connected Successfully"}, events[0])
+
+	agg, ok := events[1].(StockAggregate)
+	assert.True(t, ok)
+	assert.Equal(t, "AAPL", agg.Symbol)
+	assert.Equal(t, 190.5, agg.TickClose)
+
+	raw, ok := events[2].(RawEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "NOI", raw.Type)
+	assert.Equal(t, "MSFT", raw.EventSymbol())
+	assert.JSONEq(t, `{"ev":"NOI","sym":"MSFT","x":4}`, string(raw.Data))
+}
+
+func TestDecoderSingleObject(t *testing.T) {
+	events, err := NewDecoder(ClusterCrypto).Decode([]byte(`{"ev":"XA","pair":"BTC-USD","c":42000}`))
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{CryptoAggregate{Event: CryptoEventTypeXA, Pair: "BTC-USD", TickClose: 42000}}, events)
+
+	_, err = NewDecoder(ClusterCrypto).Decode([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestDispatcher(t *testing.T) {
+	d := NewDispatcher(ClusterForex)
+	var aggregates []ForexAggregate
+	var statuses []StatusMessage
+	var all []string
+	var errs []error
+
+	On(d, func(a ForexAggregate) { aggregates = append(aggregates, a) })
+	On(d, func(m StatusMessage) { statuses = append(statuses, m) })
+	d.OnAny(func(e Event) { all = append(all, e.EventType()) })
+	d.OnError(func(err error) { errs = append(errs, err) })
+	ch := EventChan[ForexAggregate](d, 10)
+
+	d.HandleFrame([]byte(`[{"ev":"status","status":"auth_success"},{"ev":"CA","pair":"EUR/USD","c":1.08},{"ev":"CAS","pair":"USD/JPY","c":150.1}]`))
+	d.HandleFrame([]byte(`{`))
+
+	assert.Len(t, aggregates, 2)
+	assert.Equal(t, "USD/JPY", aggregates[1].Pair)
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, []string{"status", "CA", "CAS"}, all)
+	assert.Len(t, errs, 1)
+	assert.Len(t, ch, 2)
+	assert.Equal(t, "EUR/USD", (<-ch).Pair)
+}
+
+func TestDecoderStockTradesAndQuotes(t *testing.T) {
+	frame := []byte(`[{"ev":"T","sym":"MSFT","x":4,"i":"12345","z":3,"p":114.125,"s":100,"c":[0,12],"t":1536036818784,"q":3681328,"trfi":202,"trft":1536036818780},` +
+		`{"ev":"Q","sym":"MSFT","bx":4,"bp":114.125,"bs":100,"ax":7,"ap":114.128,"as":160,"c":0,"i":[604],"t":1536036818784,"q":50385480,"z":3}]`)
+
+	events, err := NewDecoder(ClusterStocks).Decode(frame)
+	assert.NoError(t, err)
+	if !assert.Len(t, events, 2) {
+		return
+	}
+
+	assert.Equal(t, StockTrade{
+		Event: StockEventTypeT, Symbol: "MSFT", Exchange: 4, ID: "12345", Tape: 3, Price: 114.125, Size: 100,
+		Conditions: []int{0, 12}, Timestamp: 1536036818784, SequenceNumber: 3681328, TRFID: 202, TRFTimestamp: 1536036818780,
+	}, events[0])
+
+	quote, ok := events[1].(StockQuote)
+	assert.True(t, ok)
+	assert.Equal(t, 7, quote.AskExchange)
+	assert.Equal(t, int64(160), quote.AskSize)
+	assert.Equal(t, []int{604}, quote.Indicators)
+}
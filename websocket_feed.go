@@ -0,0 +1,98 @@
+package polygon
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Feed polygon websocket feed, each feed has its own host
+type Feed string
+
+const (
+	// FeedRealTime standard real-time feed, wss://socket.polygon.io
+	FeedRealTime Feed = "realtime"
+	// FeedDelayed 15-minute delayed feed, wss://delayed.polygon.io
+	FeedDelayed Feed = "delayed"
+	// FeedBusiness business feed, wss://business.polygon.io
+	FeedBusiness Feed = "business"
+)
+
+var feedURLs = map[Feed]string{
+	FeedRealTime: "wss://socket.polygon.io",
+	FeedDelayed:  "wss://delayed.polygon.io",
+	FeedBusiness: "wss://business.polygon.io",
+}
+
+// feedChannels event types available per feed and cluster
+var feedChannels = map[Feed]map[Cluster][]string{
+	FeedRealTime: {
+		ClusterStocks:  {"AM", "A", "T", "Q", "LULD"},
+		ClusterCrypto:  {"XA", "XAS", "XT", "XQ", "XL2"},
+		ClusterForex:   {"CA", "CAS", "C"},
+		ClusterOptions: {"AM", "A", "T", "Q"},
+		ClusterIndices: {"V", "AM", "A"},
+	},
+	// crypto and forex are not delayed
+	FeedDelayed: {
+		ClusterStocks:  {"AM", "A", "T", "Q", "LULD"},
+		ClusterOptions: {"AM", "A", "T", "Q"},
+		ClusterIndices: {"V", "AM", "A"},
+	},
+	// fair market value is only available on the business feed
+	FeedBusiness: {
+		ClusterStocks:  {"AM", "A", "T", "Q", "LULD", "FMV"},
+		ClusterCrypto:  {"XA", "XAS", "XT", "XQ", "XL2"},
+		ClusterForex:   {"CA", "CAS", "C"},
+		ClusterOptions: {"AM", "A", "T", "Q"},
+		ClusterIndices: {"V", "AM", "A"},
+	},
+}
+
+// ErrFeedNotSupported a cluster or channel is not available on the selected feed
+var ErrFeedNotSupported = errors.New("feed: not supported")
+
+// WithFeed selects the websocket feed of a new Polygon Client, the default is FeedBusiness.
+// The feed host is used unless WithWebsocketBaseURL is given.
+func WithFeed(feed Feed) ClientOption {
+	return func(client *Client) {
+		client.feed = feed
+	}
+}
+
+// Feed returns the websocket feed of the client
+func (c Client) Feed() Feed {
+	return c.feed
+}
+
+// CheckFeed checks that the cluster and the event type of every channel, e.g. "AM.AAPL", are available on the feed
+func CheckFeed(feed Feed, cluster Cluster, channels ...string) error {
+	clusters, ok := feedChannels[feed]
+	if !ok {
+		return fmt.Errorf("%w: unknown feed %q", ErrFeedNotSupported, feed)
+	}
+
+	eventTypes, ok := clusters[cluster]
+	if !ok {
+		return fmt.Errorf("%w: %s cluster on the %s feed", ErrFeedNotSupported, cluster, feed)
+	}
+
+	for _, channel := range channels {
+		eventType, _, _ := strings.Cut(channel, ".")
+		if !slices.Contains(eventTypes, eventType) {
+			return fmt.Errorf("%w: %s channel on the %s %s feed", ErrFeedNotSupported, channel, feed, cluster)
+		}
+	}
+	return nil
+}
+
+// FeedTag tags an event with the feed it came from, it is embedded in every event
+type FeedTag struct {
+	Feed Feed `json:"-"`
+}
+
+// EventFeed implements Event
+func (t FeedTag) EventFeed() Feed { return t.Feed }
+
+func (t *FeedTag) setFeed(feed Feed) { t.Feed = feed }
@@ -0,0 +1,152 @@
+package polygon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFeed(t *testing.T) {
+	assert.NoError(t, CheckFeed(FeedRealTime, ClusterStocks, "AM.AAPL", "T.*"))
+	assert.NoError(t, CheckFeed(FeedBusiness, ClusterStocks, "FMV.AAPL"))
+	assert.NoError(t, CheckFeed(FeedDelayed, ClusterIndices, "V.I:SPX"))
+
+	assert.ErrorIs(t, CheckFeed(FeedRealTime, ClusterStocks, "FMV.AAPL"), ErrFeedNotSupported)
+	assert.ErrorIs(t, CheckFeed(FeedDelayed, ClusterCrypto, "XA.BTC-USD"), ErrFeedNotSupported)
+	assert.ErrorIs(t, CheckFeed(FeedDelayed, ClusterForex), ErrFeedNotSupported)
+	assert.ErrorIs(t, CheckFeed(FeedRealTime, ClusterCrypto, "AM.BTC-USD"), ErrFeedNotSupported)
+	assert.ErrorIs(t, CheckFeed("premium", ClusterStocks), ErrFeedNotSupported)
+}
+
+func TestWithFeed(t *testing.T) {
+	assert.Equal(t, FeedBusiness, NewClient("test-token").Feed())
+
+	for feed, u := range map[Feed]string{
+		FeedRealTime: "wss://socket.polygon.io/stocks",
+		FeedDelayed:  "wss://delayed.polygon.io/stocks",
+		FeedBusiness: "wss://business.polygon.io/stocks",
+	} {
+		conn := newFakeStreamConn()
+		conn.onWrite = polygonReplies
+
+		c := NewClient("test-token", WithFeed(feed))
+		assert.Equal(t, feed, c.Feed())
+		assert.NoError(t, c.SubscribeStockTrades(conn, []string{"AAPL"}))
+		assert.Equal(t, u, conn.dialed())
+	}
+}
+
+func TestSubscribeRejectedByFeed(t *testing.T) {
+	conn := newFakeStreamConn()
+
+	err := NewClient("test-token", WithFeed(FeedDelayed)).SubscribeCryptoTrades(conn, []string{"BTC-USD"})
+	assert.ErrorIs(t, err, ErrFeedNotSupported)
+	assert.Equal(t, 0, conn.dialCount())
+
+	err = NewClient("test-token", WithFeed(FeedRealTime)).SubscribeStockFMV(conn, []string{"AAPL"})
+	assert.ErrorIs(t, err, ErrFeedNotSupported)
+}
+
+func TestStreamRejectedByFeed(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+	client := NewClient("test-token", WithFeed(FeedRealTime))
+
+	stream := client.NewStream(conn, ClusterStocks, []string{"FMV.AAPL"})
+	assert.ErrorIs(t, stream.Run(context.Background()), ErrFeedNotSupported)
+	assert.Equal(t, 0, conn.dialCount())
+
+	stream = client.NewStream(conn, ClusterStocks, []string{"AM.AAPL"})
+	assert.ErrorIs(t, stream.Subscribe("FMV.AAPL"), ErrFeedNotSupported)
+	assert.Equal(t, []string{"AM.AAPL"}, stream.Subscriptions())
+}
+
+func TestStreamUnknownChannelRejectedLocally(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+
+	stream := NewClient("test-token").NewStream(conn, ClusterStocks, []string{"ZZ.AAPL"})
+	assert.ErrorIs(t, stream.Run(context.Background()), ErrFeedNotSupported)
+	assert.Equal(t, 0, conn.dialCount())
+	assert.Empty(t, conn.written())
+}
+
+func TestWithFeedKeepsWebsocketBaseURL(t *testing.T) {
+	for _, opts := range [][]ClientOption{
+		{WithWebsocketBaseURL("wss://proxy"), WithFeed(FeedDelayed)},
+		{WithFeed(FeedDelayed), WithWebsocketBaseURL("wss://proxy")},
+	} {
+		conn := newFakeStreamConn()
+		conn.onWrite = polygonReplies
+
+		c := NewClient("test-token", opts...)
+		assert.Equal(t, FeedDelayed, c.Feed())
+		assert.NoError(t, c.SubscribeStockTrades(conn, []string{"AAPL"}))
+		assert.Equal(t, "wss://proxy/stocks", conn.dialed())
+	}
+}
+
+func TestWithDispatcherKeepsDispatcherFeed(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+
+	d := NewFeedDispatcher(FeedRealTime, ClusterStocks)
+	aggs := EventChan[StockAggregate](d, 1)
+
+	stream := NewClient("test-token", WithFeed(FeedDelayed)).NewStream(conn, ClusterStocks, []string{"AM.AAPL"}, WithDispatcher(d))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go stream.Run(ctx)
+
+	assert.Eventually(t, func() bool { return stream.State() == StreamStateConnected }, time.Second, time.Millisecond)
+	conn.push(`[{"ev":"AM","sym":"AAPL","c":190.5}]`)
+
+	select {
+	case agg := <-aggs:
+		assert.Equal(t, FeedRealTime, agg.EventFeed())
+	case <-time.After(time.Second):
+		t.Fatal("no aggregate")
+	}
+}
+
+func TestStreamEventsTaggedWithFeed(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+
+	d := NewDispatcher(ClusterStocks)
+	aggs := EventChan[StockAggregate](d, 1)
+	raws := EventChan[RawEvent](d, 1)
+
+	stream := NewClient("test-token", WithFeed(FeedDelayed)).NewStream(conn, ClusterStocks, []string{"AM.AAPL"}, WithDispatcher(d))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go stream.Run(ctx)
+
+	assert.Eventually(t, func() bool { return stream.State() == StreamStateConnected }, time.Second, time.Millisecond)
+	conn.push(`[{"ev":"AM","sym":"AAPL","c":190.5},{"ev":"NOI","sym":"AAPL"}]`)
+
+	select {
+	case agg := <-aggs:
+		assert.Equal(t, FeedDelayed, agg.EventFeed())
+	case <-time.After(time.Second):
+		t.Fatal("no aggregate")
+	}
+	select {
+	case raw := <-raws:
+		assert.Equal(t, FeedDelayed, raw.EventFeed())
+	case <-time.After(time.Second):
+		t.Fatal("no raw event")
+	}
+}
+
+func TestFeedDecoder(t *testing.T) {
+	events, err := NewFeedDecoder(FeedRealTime, ClusterCrypto).Decode([]byte(`[{"ev":"XT","pair":"BTC-USD","p":1}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{CryptoTrade{FeedTag: FeedTag{Feed: FeedRealTime}, Event: CryptoEventTypeXT, Pair: "BTC-USD", Price: 1}}, events)
+
+	events, err = NewDecoder(ClusterCrypto).Decode([]byte(`[{"ev":"XT","pair":"BTC-USD","p":1}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, Feed(""), events[0].EventFeed())
+}
@@ -1,9 +1,6 @@
 package polygon
 
-import (
-	"fmt"
-	"strings"
-)
+import "fmt"
 
 // ForexEventTypeEnum event type enum
 type ForexEventTypeEnum string
@@ -15,9 +12,12 @@ const (
 	ForexEventTypeCA ForexEventTypeEnum = "CA"
 	// ForexEventTypeCAS second aggregates
 	ForexEventTypeCAS ForexEventTypeEnum = "CAS"
+	// ForexEventTypeC quotes
+	ForexEventTypeC ForexEventTypeEnum = "C"
 )
 
 type ForexAggregate struct {
+	FeedTag
 	Event                 ForexEventTypeEnum `json:"ev"`   // The event type.
 	Pair                  string             `json:"pair"` // The current pair.
 	TickOpen              float64            `json:"o"`    // The opening tick price for this aggregate window.
@@ -31,32 +31,44 @@ type ForexAggregate struct {
 	PerformancePercentage float64            // performance percentage from last market close
 }
 
-func (c Client) SubscribeForexAggregates(client WebSocketClient, pairs []string, eventType ForexEventTypeEnum) (err error) {
-	// connect
-	client.Dial(fmt.Sprintf("%s/forex", c.websocketBaseURL), nil)
-	// auth
-	err = client.WriteMessage(TextMessage, []byte(fmt.Sprintf("{\"action\":\"auth\",\"params\":\"%s\"}", c.token)))
-	if err != nil {
-		return
-	}
+// EventType implements Event
+func (a ForexAggregate) EventType() string { return string(a.Event) }
 
-	channel := resolveForexChannel(pairs, eventType)
-	err = client.WriteMessage(TextMessage, []byte(fmt.Sprintf("{\"action\":\"subscribe\",\"params\":\"%s\"}", channel)))
-	if err != nil {
-		return
-	}
+// EventSymbol implements Event
+func (a ForexAggregate) EventSymbol() string { return a.Pair }
 
-	return
+// ForexQuote quote of a currency pair
+type ForexQuote struct {
+	FeedTag
+	Event     ForexEventTypeEnum `json:"ev"` // The event type.
+	Pair      string             `json:"p"`  // The current pair.
+	Exchange  int                `json:"x"`  // The exchange ID.
+	AskPrice  float64            `json:"a"`  // The ask price.
+	BidPrice  float64            `json:"b"`  // The bid price.
+	Timestamp int64              `json:"t"`  // The timestamp in Unix Milliseconds.
 }
 
-func resolveForexChannel(pairs []string, eventType ForexEventTypeEnum) string {
-	var sb strings.Builder
-	for i, pair := range pairs {
-		sb.WriteString(fmt.Sprintf("%s.%s", eventType, pair))
-		if i < len(pairs)-1 {
-			sb.WriteString(",")
-		}
-	}
+// EventType implements Event
+func (q ForexQuote) EventType() string { return string(q.Event) }
+
+// EventSymbol implements Event
+func (q ForexQuote) EventSymbol() string { return q.Pair }
 
-	return sb.String()
+// SubscribeForexQuotes subscribes to the quotes (C) of the given pairs
+func (c Client) SubscribeForexQuotes(client WebSocketClient, pairs []string) error {
+	return c.SubscribeForexAggregates(client, pairs, ForexEventTypeC)
+}
+
+// SubscribeForexAggregates subscribes to the given event type of the given pairs on the forex cluster
+func (c Client) SubscribeForexAggregates(client WebSocketClient, pairs []string, eventType ForexEventTypeEnum) error {
+	return c.subscribe(client, ClusterForex, ForexChannels(eventType, pairs...))
+}
+
+// ForexChannels returns the channels of the given event type for every pair, e.g. "CA.EUR/USD"
+func ForexChannels(eventType ForexEventTypeEnum, pairs ...string) []string {
+	channels := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		channels = append(channels, fmt.Sprintf("%s.%s", eventType, pair))
+	}
+	return channels
 }
@@ -0,0 +1,154 @@
+package polygon
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// FXRate bid, ask and mid of a currency pair
+type FXRate struct {
+	Pair      string // The pair as BASE/QUOTE, e.g. "EUR/USD".
+	Bid       float64
+	Ask       float64
+	Timestamp int64 // The timestamp in Unix Milliseconds, the oldest leg for derived rates.
+	Derived   bool  // The rate is an inverse or a cross of the tracked pairs.
+}
+
+// Mid returns the mid price
+func (r FXRate) Mid() float64 { return (r.Bid + r.Ask) / 2 }
+
+// invert returns the rate of QUOTE/BASE
+func (r FXRate) invert() FXRate {
+	base, quote, _ := strings.Cut(r.Pair, "/")
+	return FXRate{
+		Pair:      quote + "/" + base,
+		Bid:       1 / r.Ask,
+		Ask:       1 / r.Bid,
+		Timestamp: r.Timestamp,
+		Derived:   true,
+	}
+}
+
+// FXTracker keeps the latest rate of every currency pair from forex quotes, safe for concurrent use
+type FXTracker struct {
+	mu    sync.RWMutex
+	rates map[string]FXRate
+}
+
+// NewFXTracker creates an empty tracker
+func NewFXTracker() *FXTracker {
+	return &FXTracker{rates: make(map[string]FXRate)}
+}
+
+// Attach feeds the tracker with the quotes of a forex dispatcher
+func (f *FXTracker) Attach(d *Dispatcher) {
+	On(d, f.Update)
+}
+
+// Update stores a quote, older quotes and quotes without a positive bid and ask are ignored
+func (f *FXTracker) Update(q ForexQuote) {
+	pair, ok := normalizePair(q.Pair)
+	if !ok || q.BidPrice <= 0 || q.AskPrice <= 0 {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if current, ok := f.rates[pair]; ok && current.Timestamp > q.Timestamp {
+		return
+	}
+	f.rates[pair] = FXRate{Pair: pair, Bid: q.BidPrice, Ask: q.AskPrice, Timestamp: q.Timestamp}
+}
+
+// Rate returns the rate of a pair such as "EUR/JPY", "EUR-JPY" or "C:EURJPY".
+// When the pair is not tracked it is derived from its inverse, or crossed through
+// a common currency, USD first, e.g. EUR/JPY from EUR/USD and USD/JPY.
+func (f *FXTracker) Rate(pair string) (FXRate, bool) {
+	pair, ok := normalizePair(pair)
+	if !ok {
+		return FXRate{}, false
+	}
+	base, quote, _ := strings.Cut(pair, "/")
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if rate, ok := f.leg(base, quote); ok {
+		return rate, true
+	}
+
+	for _, via := range f.currencies() {
+		if via == base || via == quote {
+			continue
+		}
+
+		first, ok := f.leg(base, via)
+		if !ok {
+			continue
+		}
+		second, ok := f.leg(via, quote)
+		if !ok {
+			continue
+		}
+
+		return FXRate{
+			Pair:      pair,
+			Bid:       first.Bid * second.Bid,
+			Ask:       first.Ask * second.Ask,
+			Timestamp: min(first.Timestamp, second.Timestamp),
+			Derived:   true,
+		}, true
+	}
+	return FXRate{}, false
+}
+
+// Mid returns the mid price of a pair, see Rate
+func (f *FXTracker) Mid(pair string) (float64, bool) {
+	rate, ok := f.Rate(pair)
+	return rate.Mid(), ok
+}
+
+// leg returns the direct or inverse rate of base/quote, it must be called with mu held
+func (f *FXTracker) leg(base, quote string) (FXRate, bool) {
+	if rate, ok := f.rates[base+"/"+quote]; ok {
+		return rate, true
+	}
+	if rate, ok := f.rates[quote+"/"+base]; ok {
+		return rate.invert(), true
+	}
+	return FXRate{}, false
+}
+
+// currencies returns the tracked currencies, USD first, it must be called with mu held
+func (f *FXTracker) currencies() []string {
+	set := make(map[string]struct{})
+	for pair := range f.rates {
+		base, quote, _ := strings.Cut(pair, "/")
+		set[base] = struct{}{}
+		set[quote] = struct{}{}
+	}
+
+	currencies := slices.Sorted(maps.Keys(set))
+	if i := slices.Index(currencies, "USD"); i > 0 {
+		currencies = append([]string{"USD"}, slices.Delete(currencies, i, i+1)...)
+	}
+	return currencies
+}
+
+// normalizePair converts "EUR/USD", "eur-usd", "C:EURUSD" or "C:EUR-USD" to "EUR/USD"
+func normalizePair(pair string) (string, bool) {
+	pair = strings.TrimPrefix(strings.ToUpper(pair), "C:")
+	pair = strings.ReplaceAll(pair, "-", "/")
+	if !strings.Contains(pair, "/") && len(pair) == 6 {
+		pair = pair[:3] + "/" + pair[3:]
+	}
+
+	base, quote, ok := strings.Cut(pair, "/")
+	if !ok || base == "" || quote == "" || base == quote || strings.Contains(quote, "/") {
+		return "", false
+	}
+	return pair, true
+}
@@ -0,0 +1,87 @@
+package polygon
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoderForexQuote(t *testing.T) {
+	events, err := NewDecoder(ClusterForex).Decode([]byte(`[{"ev":"C","p":"USD/CNH","x":44,"a":6.83366,"b":6.83363,"t":1536036818784}]`))
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{ForexQuote{Event: ForexEventTypeC, Pair: "USD/CNH", Exchange: 44, AskPrice: 6.83366, BidPrice: 6.83363, Timestamp: 1536036818784}}, events)
+	assert.Equal(t, "USD/CNH", events[0].EventSymbol())
+}
+
+func TestNormalizePair(t *testing.T) {
+	for _, pair := range []string{"EUR/USD", "eur-usd", "C:EURUSD", "C:EUR-USD"} {
+		normalized, ok := normalizePair(pair)
+		assert.True(t, ok, pair)
+		assert.Equal(t, "EUR/USD", normalized, pair)
+	}
+
+	for _, pair := range []string{"", "EUR", "EUR/", "EUR/EUR", "EUR/USD/JPY"} {
+		_, ok := normalizePair(pair)
+		assert.False(t, ok, pair)
+	}
+}
+
+func TestFXTracker(t *testing.T) {
+	tracker := NewFXTracker()
+	d := NewDispatcher(ClusterForex)
+	tracker.Attach(d)
+
+	d.HandleFrame([]byte(`[{"ev":"C","p":"EUR/USD","a":1.11,"b":1.09,"t":10},` +
+		`{"ev":"C","p":"USD/JPY","a":151,"b":149,"t":20},` +
+		`{"ev":"C","p":"EUR/USD","a":2,"b":1,"t":5}]`))
+
+	// direct, the older quote is ignored
+	rate, ok := tracker.Rate("EUR/USD")
+	assert.True(t, ok)
+	assert.Equal(t, FXRate{Pair: "EUR/USD", Bid: 1.09, Ask: 1.11, Timestamp: 10}, rate)
+	assert.InDelta(t, 1.10, rate.Mid(), 1e-9)
+
+	// inverse
+	rate, ok = tracker.Rate("JPY/USD")
+	assert.True(t, ok)
+	assert.True(t, rate.Derived)
+	assert.InDelta(t, 1.0/151, rate.Bid, 1e-12)
+	assert.InDelta(t, 1.0/149, rate.Ask, 1e-12)
+
+	// cross through USD
+	rate, ok = tracker.Rate("C:EURJPY")
+	assert.True(t, ok)
+	assert.Equal(t, "EUR/JPY", rate.Pair)
+	assert.InDelta(t, 1.09*149, rate.Bid, 1e-9)
+	assert.InDelta(t, 1.11*151, rate.Ask, 1e-9)
+	assert.Equal(t, int64(10), rate.Timestamp)
+
+	mid, ok := tracker.Mid("JPY/EUR")
+	assert.True(t, ok)
+	assert.InDelta(t, (1/(1.11*151)+1/(1.09*149))/2, mid, 1e-12)
+
+	_, ok = tracker.Rate("GBP/JPY")
+	assert.False(t, ok)
+}
+
+func TestFXTrackerConcurrent(t *testing.T) {
+	tracker := NewFXTracker()
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			tracker.Update(ForexQuote{Pair: "EUR/USD", BidPrice: 1, AskPrice: 1.2, Timestamp: int64(i)})
+			tracker.Update(ForexQuote{Pair: "USD/JPY", BidPrice: 150, AskPrice: 151, Timestamp: int64(i)})
+		}()
+		go func() {
+			defer wg.Done()
+			tracker.Mid("EUR/JPY")
+		}()
+	}
+	wg.Wait()
+
+	_, ok := tracker.Mid("EUR/JPY")
+	assert.True(t, ok)
+}
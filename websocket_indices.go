@@ -0,0 +1,85 @@
+package polygon
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IndexEventTypeEnum event type enum
+type IndexEventTypeEnum string
+
+const (
+	// IndexEventTypeOther others
+	IndexEventTypeOther IndexEventTypeEnum = ""
+	// IndexEventTypeV values
+	IndexEventTypeV IndexEventTypeEnum = "V"
+	// IndexEventTypeAM minute aggregates
+	IndexEventTypeAM IndexEventTypeEnum = "AM"
+	// IndexEventTypeA second aggregates
+	IndexEventTypeA IndexEventTypeEnum = "A"
+)
+
+// IndexValue value of an index
+type IndexValue struct {
+	FeedTag
+	Event     IndexEventTypeEnum `json:"ev"`  // The event type.
+	Symbol    string             `json:"T"`   // The ticker symbol for the given index.
+	Value     float64            `json:"val"` // The value of the index.
+	Timestamp int64              `json:"t"`   // The timestamp in Unix Milliseconds.
+}
+
+// EventType implements Event
+func (v IndexValue) EventType() string { return string(v.Event) }
+
+// EventSymbol implements Event
+func (v IndexValue) EventSymbol() string { return v.Symbol }
+
+// IndexAggregate minute or second aggregate of an index
+type IndexAggregate struct {
+	FeedTag
+	Event          IndexEventTypeEnum `json:"ev"`  // The event type.
+	Symbol         string             `json:"sym"` // The ticker symbol for the given index.
+	Open           float64            `json:"op"`  // Today's official opening value.
+	TickOpen       float64            `json:"o"`   // The opening index value for this aggregate window.
+	TickClose      float64            `json:"c"`   // The closing index value for this aggregate window.
+	TickHigh       float64            `json:"h"`   // The highest index value for this aggregate window.
+	TickLow        float64            `json:"l"`   // The lowest index value for this aggregate window.
+	StartTimestamp int64              `json:"s"`   // The timestamp of the starting tick for this aggregate window in Unix Milliseconds.
+	EndTimestamp   int64              `json:"e"`   // The timestamp of the ending tick for this aggregate window in Unix Milliseconds.
+}
+
+// EventType implements Event
+func (a IndexAggregate) EventType() string { return string(a.Event) }
+
+// EventSymbol implements Event
+func (a IndexAggregate) EventSymbol() string { return a.Symbol }
+
+// SubscribeIndexValues subscribes to the values (V) of the given indices
+func (c Client) SubscribeIndexValues(client WebSocketClient, indices []string) error {
+	return c.SubscribeIndexAggregates(client, indices, IndexEventTypeV)
+}
+
+// SubscribeIndexAggregates subscribes to the given event type of the given indices on the indices cluster.
+// Indices such as "SPX" or "I:SPX" are subscribed as "I:SPX".
+func (c Client) SubscribeIndexAggregates(client WebSocketClient, indices []string, eventType IndexEventTypeEnum) error {
+	// https://polygon.io/docs/indices/ws_indices_am
+	return c.subscribe(client, ClusterIndices, IndexChannels(eventType, indices...))
+}
+
+// IndexChannels returns the channels of the given event type for every index, e.g. "V.I:SPX"
+func IndexChannels(eventType IndexEventTypeEnum, indices ...string) []string {
+	channels := make([]string, 0, len(indices))
+	for _, index := range indices {
+		channels = append(channels, fmt.Sprintf("%s.%s", eventType, indexSymbol(index)))
+	}
+	return channels
+}
+
+// indexSymbol upper-cases an index and adds the "I:" prefix, wildcards are left as is
+func indexSymbol(index string) string {
+	index = strings.ToUpper(index)
+	if index == "*" || strings.HasPrefix(index, "I:") {
+		return index
+	}
+	return "I:" + index
+}
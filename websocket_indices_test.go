@@ -0,0 +1,34 @@
+package polygon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoderIndices(t *testing.T) {
+	frame := []byte(`[{"ev":"V","val":3988.5,"T":"I:SPX","t":1678220098130},` +
+		`{"ev":"AM","sym":"I:SPX","op":3985.67,"o":3985.67,"c":3985.67,"h":3985.67,"l":3985.67,"s":1678220675805,"e":1678220675805}]`)
+
+	events, err := NewDecoder(ClusterIndices).Decode(frame)
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{
+		IndexValue{Event: IndexEventTypeV, Symbol: "I:SPX", Value: 3988.5, Timestamp: 1678220098130},
+		IndexAggregate{Event: IndexEventTypeAM, Symbol: "I:SPX", Open: 3985.67, TickOpen: 3985.67, TickClose: 3985.67, TickHigh: 3985.67, TickLow: 3985.67, StartTimestamp: 1678220675805, EndTimestamp: 1678220675805},
+	}, events)
+	assert.Equal(t, "I:SPX", events[0].EventSymbol())
+}
+
+func TestSubscribeIndexValues(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+
+	assert.NoError(t, NewClient("test-token").SubscribeIndexValues(conn, []string{"I:SPX", "ndx"}))
+	assert.Equal(t, "wss://business.polygon.io/indices", conn.dialed())
+	assert.Equal(t, `{"action":"subscribe","params":"V.I:SPX,V.I:NDX"}`, conn.written()[1])
+}
+
+func TestIndexChannelsPrefixCase(t *testing.T) {
+	assert.Equal(t, []string{"V.I:SPX", "V.I:SPX", "V.I:NDX", "V.*"},
+		IndexChannels(IndexEventTypeV, "i:spx", "I:spx", "ndx", "*"))
+}
@@ -0,0 +1,92 @@
+package polygon
+
+import "sync"
+
+// LULDBreachSide side of the band that was breached
+type LULDBreachSide string
+
+const (
+	LULDBreachUp   LULDBreachSide = "up"
+	LULDBreachDown LULDBreachSide = "down"
+)
+
+// LULDBreach a trade printed at or beyond the limit up - limit down band of its symbol
+type LULDBreach struct {
+	FeedTag
+	Symbol    string
+	Side      LULDBreachSide
+	Price     float64
+	Band      LimitUpLimitDown
+	Timestamp int64 // timestamp of the trade in Unix Milliseconds
+}
+
+// EventType implements Event
+func (b LULDBreach) EventType() string { return "LULD_BREACH" }
+
+// EventSymbol implements Event
+func (b LULDBreach) EventSymbol() string { return b.Symbol }
+
+// LULDMonitor keeps the latest LULD band of every symbol and reports trades breaching it
+type LULDMonitor struct {
+	mu       sync.RWMutex
+	bands    map[string]LimitUpLimitDown
+	onBreach func(LULDBreach)
+}
+
+// NewLULDMonitor creates a monitor calling onBreach for every breach
+func NewLULDMonitor(onBreach func(LULDBreach)) *LULDMonitor {
+	return &LULDMonitor{
+		bands:    make(map[string]LimitUpLimitDown),
+		onBreach: onBreach,
+	}
+}
+
+// Attach feeds the monitor with the LULD and trade events of a stocks dispatcher
+func (m *LULDMonitor) Attach(d *Dispatcher) {
+	On(d, m.Update)
+	On(d, func(t StockTrade) {
+		m.Check(t.Symbol, t.Price, t.Timestamp)
+	})
+}
+
+// Update stores the latest band of a symbol, older bands are ignored
+func (m *LULDMonitor) Update(band LimitUpLimitDown) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if current, ok := m.bands[band.Symbol]; ok && current.Timestamp > band.Timestamp {
+		return
+	}
+	m.bands[band.Symbol] = band
+}
+
+// Band returns the latest band of a symbol
+func (m *LULDMonitor) Band(symbol string) (LimitUpLimitDown, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	band, ok := m.bands[symbol]
+	return band, ok
+}
+
+// Check reports whether price breaches the band of symbol, calling onBreach if so
+func (m *LULDMonitor) Check(symbol string, price float64, timestamp int64) (LULDBreach, bool) {
+	band, ok := m.Band(symbol)
+	if !ok || price <= 0 {
+		return LULDBreach{}, false
+	}
+
+	breach := LULDBreach{FeedTag: band.FeedTag, Symbol: symbol, Price: price, Band: band, Timestamp: timestamp}
+	switch {
+	case band.HighLimitPrice > 0 && price >= band.HighLimitPrice:
+		breach.Side = LULDBreachUp
+	case band.LowLimitPrice > 0 && price <= band.LowLimitPrice:
+		breach.Side = LULDBreachDown
+	default:
+		return LULDBreach{}, false
+	}
+
+	if m.onBreach != nil {
+		m.onBreach(breach)
+	}
+	return breach, true
+}
@@ -0,0 +1,54 @@
+package polygon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoderLULDAndFMV(t *testing.T) {
+	frame := []byte(`[{"ev":"LULD","T":"MSFT","h":218.96,"l":198.11,"i":[21],"z":3,"t":1601316752683,"q":449},` +
+		`{"ev":"FMV","fmv":189.22,"sym":"AAPL","t":1678220098130000000}]`)
+
+	events, err := NewDecoder(ClusterStocks).Decode(frame)
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{
+		LimitUpLimitDown{Event: StockEventTypeLULD, Symbol: "MSFT", HighLimitPrice: 218.96, LowLimitPrice: 198.11, Indicators: []int{21}, Tape: 3, Timestamp: 1601316752683, SequenceNumber: 449},
+		FairMarketValue{Event: StockEventTypeFMV, Symbol: "AAPL", FMV: 189.22, Timestamp: 1678220098130000000},
+	}, events)
+	assert.Equal(t, "MSFT", events[0].EventSymbol())
+	assert.Equal(t, time.UnixMilli(1678220098130), events[1].(FairMarketValue).Time())
+}
+
+func TestLULDMonitor(t *testing.T) {
+	var breaches []LULDBreach
+	m := NewLULDMonitor(func(b LULDBreach) { breaches = append(breaches, b) })
+
+	d := NewDispatcher(ClusterStocks)
+	m.Attach(d)
+
+	// no band yet
+	d.HandleFrame([]byte(`{"ev":"T","sym":"MSFT","p":250,"t":1}`))
+	assert.Empty(t, breaches)
+
+	d.HandleFrame([]byte(`{"ev":"LULD","T":"MSFT","h":220,"l":200,"t":10}`))
+	// an older band is ignored
+	d.HandleFrame([]byte(`{"ev":"LULD","T":"MSFT","h":300,"l":100,"t":5}`))
+	band, ok := m.Band("MSFT")
+	assert.True(t, ok)
+	assert.Equal(t, 220.0, band.HighLimitPrice)
+
+	d.HandleFrame([]byte(`[{"ev":"T","sym":"MSFT","p":210,"t":11},` +
+		`{"ev":"T","sym":"MSFT","p":220,"t":12},` +
+		`{"ev":"T","sym":"MSFT","p":199.5,"t":13},` +
+		`{"ev":"T","sym":"AAPL","p":1,"t":14}]`))
+	if assert.Len(t, breaches, 2) {
+		assert.Equal(t, LULDBreach{Symbol: "MSFT", Side: LULDBreachUp, Price: 220, Band: band, Timestamp: 12}, breaches[0])
+		assert.Equal(t, LULDBreachDown, breaches[1].Side)
+		assert.Equal(t, 199.5, breaches[1].Price)
+	}
+
+	_, ok = m.Check("MSFT", 205, 15)
+	assert.False(t, ok)
+}
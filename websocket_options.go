@@ -0,0 +1,190 @@
+package polygon
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OptionEventTypeEnum event type enum
+type OptionEventTypeEnum string
+
+const (
+	// OptionEventTypeOther others
+	OptionEventTypeOther OptionEventTypeEnum = ""
+	// OptionEventTypeAM minute aggregates
+	OptionEventTypeAM OptionEventTypeEnum = "AM"
+	// OptionEventTypeA second aggregates
+	OptionEventTypeA OptionEventTypeEnum = "A"
+	// OptionEventTypeT trades
+	OptionEventTypeT OptionEventTypeEnum = "T"
+	// OptionEventTypeQ quotes
+	OptionEventTypeQ OptionEventTypeEnum = "Q"
+)
+
+// OptionAggregate minute or second aggregate of an option contract
+type OptionAggregate struct {
+	FeedTag
+	Event             OptionEventTypeEnum `json:"ev"`  // The event type.
+	Symbol            string              `json:"sym"` // The ticker symbol for the given option contract.
+	TickVolume        float64             `json:"v"`   // The tick volume.
+	AccumulatedVolume int64               `json:"av"`  // Today's accumulated volume.
+	Open              float64             `json:"op"`  // Today's official opening price.
+	TickVWAP          float64             `json:"vw"`  // The tick's volume weighted average price.
+	TickOpen          float64             `json:"o"`   // The opening tick price for this aggregate window.
+	TickClose         float64             `json:"c"`   // The closing tick price for this aggregate window.
+	TickHigh          float64             `json:"h"`   // The highest tick price for this aggregate window.
+	TickLow           float64             `json:"l"`   // The lowest tick price for this aggregate window.
+	VWAP              float64             `json:"a"`   // Today's volume weighted average price.
+	AverageTradeSize  float64             `json:"z"`   // The average trade size for this aggregate window.
+	StartTimestamp    int64               `json:"s"`   // The timestamp of the starting tick for this aggregate window in Unix Milliseconds.
+	EndTimestamp      int64               `json:"e"`   // The timestamp of the ending tick for this aggregate window in Unix Milliseconds.
+}
+
+// EventType implements Event
+func (a OptionAggregate) EventType() string { return string(a.Event) }
+
+// EventSymbol implements Event
+func (a OptionAggregate) EventSymbol() string { return a.Symbol }
+
+// OptionTrade tick-level trade of an option contract
+type OptionTrade struct {
+	FeedTag
+	Event          OptionEventTypeEnum `json:"ev"`  // The event type.
+	Symbol         string              `json:"sym"` // The ticker symbol for the given option contract.
+	Exchange       int                 `json:"x"`   // The exchange ID.
+	Price          float64             `json:"p"`   // The price.
+	Size           int64               `json:"s"`   // The trade size.
+	Conditions     []int               `json:"c"`   // The trade conditions.
+	Timestamp      int64               `json:"t"`   // The timestamp in Unix Milliseconds.
+	SequenceNumber int64               `json:"q"`   // The sequence number, unique per contract and increasing.
+}
+
+// EventType implements Event
+func (t OptionTrade) EventType() string { return string(t.Event) }
+
+// EventSymbol implements Event
+func (t OptionTrade) EventSymbol() string { return t.Symbol }
+
+// OptionQuote quote of an option contract
+type OptionQuote struct {
+	FeedTag
+	Event          OptionEventTypeEnum `json:"ev"`  // The event type.
+	Symbol         string              `json:"sym"` // The ticker symbol for the given option contract.
+	BidExchange    int                 `json:"bx"`  // The bid exchange ID.
+	AskExchange    int                 `json:"ax"`  // The ask exchange ID.
+	BidPrice       float64             `json:"bp"`  // The bid price.
+	AskPrice       float64             `json:"ap"`  // The ask price.
+	BidSize        int64               `json:"bs"`  // The bid size.
+	AskSize        int64               `json:"as"`  // The ask size.
+	Timestamp      int64               `json:"t"`   // The timestamp in Unix Milliseconds.
+	SequenceNumber int64               `json:"q"`   // The sequence number, unique per contract and increasing.
+}
+
+// EventType implements Event
+func (q OptionQuote) EventType() string { return string(q.Event) }
+
+// EventSymbol implements Event
+func (q OptionQuote) EventSymbol() string { return q.Symbol }
+
+// ErrInvalidOptionSymbol a symbol is not an OCC option symbol such as "O:SPY251219C00650000"
+var ErrInvalidOptionSymbol = errors.New("invalid option symbol")
+
+// OptionContract an option contract parsed from its OCC symbol
+type OptionContract struct {
+	Underlying string
+	Expiration time.Time
+	Type       string // "call" or "put"
+	Strike     float64
+}
+
+// ParseOptionSymbol parses an OCC option symbol, with or without the "O:" prefix,
+// made of the underlying, the expiration as YYMMDD, C or P and the strike times 1000 on 8 digits
+func ParseOptionSymbol(symbol string) (OptionContract, error) {
+	occ := strings.TrimPrefix(strings.ToUpper(symbol), "O:")
+	if len(occ) < 16 || len(occ) > 21 {
+		return OptionContract{}, fmt.Errorf("%w: %q", ErrInvalidOptionSymbol, symbol)
+	}
+
+	root, date, kind, strike := occ[:len(occ)-15], occ[len(occ)-15:len(occ)-9], occ[len(occ)-9], occ[len(occ)-8:]
+	expiration, err := time.Parse("060102", date)
+	if err != nil {
+		return OptionContract{}, fmt.Errorf("%w: %q", ErrInvalidOptionSymbol, symbol)
+	}
+
+	contract := OptionContract{Underlying: root, Expiration: expiration}
+	switch kind {
+	case 'C':
+		contract.Type = "call"
+	case 'P':
+		contract.Type = "put"
+	default:
+		return OptionContract{}, fmt.Errorf("%w: %q", ErrInvalidOptionSymbol, symbol)
+	}
+
+	thousandths, err := strconv.ParseUint(strike, 10, 64)
+	if err != nil {
+		return OptionContract{}, fmt.Errorf("%w: %q", ErrInvalidOptionSymbol, symbol)
+	}
+	contract.Strike = float64(thousandths) / 1000
+
+	return contract, nil
+}
+
+// SubscribeOptionsTrades subscribes to the trades (T) of the given contracts
+func (c Client) SubscribeOptionsTrades(client WebSocketClient, contracts []string) error {
+	return c.SubscribeOptionsAggregates(client, contracts, OptionEventTypeT)
+}
+
+// SubscribeOptionsQuotes subscribes to the quotes (Q) of the given contracts
+func (c Client) SubscribeOptionsQuotes(client WebSocketClient, contracts []string) error {
+	return c.SubscribeOptionsAggregates(client, contracts, OptionEventTypeQ)
+}
+
+// SubscribeOptionsChain subscribes to the given event type of the contracts of an underlying,
+// contracts of other underlyings are left out
+func (c Client) SubscribeOptionsChain(client WebSocketClient, underlying string, contracts []string, eventType OptionEventTypeEnum) error {
+	return c.SubscribeOptionsAggregates(client, OptionChain(underlying, contracts), eventType)
+}
+
+// SubscribeOptionsAggregates subscribes to the given event type of the given contracts on the options cluster.
+// Contracts are OCC symbols, the "O:" prefix is added when missing.
+func (c Client) SubscribeOptionsAggregates(client WebSocketClient, contracts []string, eventType OptionEventTypeEnum) error {
+	// https://polygon.io/docs/options/ws_options_am
+	return c.subscribe(client, ClusterOptions, OptionChannels(eventType, contracts...))
+}
+
+// OptionChain returns the contracts of the given underlying, with the "O:" prefix
+func OptionChain(underlying string, contracts []string) []string {
+	underlying = strings.ToUpper(underlying)
+
+	chain := make([]string, 0, len(contracts))
+	for _, contract := range contracts {
+		parsed, err := ParseOptionSymbol(contract)
+		if err != nil || parsed.Underlying != underlying {
+			continue
+		}
+		chain = append(chain, optionSymbol(contract))
+	}
+	return chain
+}
+
+// OptionChannels returns the channels of the given event type for every contract, e.g. "T.O:SPY251219C00650000"
+func OptionChannels(eventType OptionEventTypeEnum, contracts ...string) []string {
+	channels := make([]string, 0, len(contracts))
+	for _, contract := range contracts {
+		channels = append(channels, fmt.Sprintf("%s.%s", eventType, optionSymbol(contract)))
+	}
+	return channels
+}
+
+// optionSymbol upper-cases an OCC symbol and adds the "O:" prefix, wildcards are left as is
+func optionSymbol(contract string) string {
+	contract = strings.ToUpper(contract)
+	if contract == "*" || strings.HasPrefix(contract, "O:") {
+		return contract
+	}
+	return "O:" + contract
+}
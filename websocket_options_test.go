@@ -0,0 +1,65 @@
+package polygon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoderOptions(t *testing.T) {
+	frame := []byte(`[{"ev":"AM","sym":"O:ONEM220121C00025000","v":2,"av":8,"op":2.2,"vw":2.05,"o":2.05,"c":2.05,"h":2.05,"l":2.05,"a":2.1312,"z":1,"s":1636573440000,"e":1636573500000},` +
+		`{"ev":"T","sym":"O:AMC210827C00037000","x":65,"p":1.54,"s":1,"c":[233],"t":1629820676333,"q":651921},` +
+		`{"ev":"Q","sym":"O:SPY241220P00720000","bx":302,"ax":302,"bp":9.71,"ap":9.81,"bs":17,"as":24,"t":1644506128351,"q":844090872}]`)
+
+	events, err := NewDecoder(ClusterOptions).Decode(frame)
+	assert.NoError(t, err)
+	if !assert.Len(t, events, 3) {
+		return
+	}
+
+	agg, ok := events[0].(OptionAggregate)
+	assert.True(t, ok)
+	assert.Equal(t, int64(8), agg.AccumulatedVolume)
+	assert.Equal(t, OptionTrade{Event: OptionEventTypeT, Symbol: "O:AMC210827C00037000", Exchange: 65, Price: 1.54, Size: 1, Conditions: []int{233}, Timestamp: 1629820676333, SequenceNumber: 651921}, events[1])
+	assert.Equal(t, OptionQuote{Event: OptionEventTypeQ, Symbol: "O:SPY241220P00720000", BidExchange: 302, AskExchange: 302, BidPrice: 9.71, AskPrice: 9.81, BidSize: 17, AskSize: 24, Timestamp: 1644506128351, SequenceNumber: 844090872}, events[2])
+}
+
+func TestParseOptionSymbol(t *testing.T) {
+	contract, err := ParseOptionSymbol("O:SPY251219C00650500")
+	assert.NoError(t, err)
+	assert.Equal(t, OptionContract{Underlying: "SPY", Expiration: time.Date(2025, 12, 19, 0, 0, 0, 0, time.UTC), Type: "call", Strike: 650.5}, contract)
+
+	contract, err = ParseOptionSymbol("brkb250117p00400000")
+	assert.NoError(t, err)
+	assert.Equal(t, "BRKB", contract.Underlying)
+	assert.Equal(t, "put", contract.Type)
+
+	for _, symbol := range []string{"", "O:SPY", "O:SPY251319C00650000", "O:SPY251219X00650000", "O:SPY251219C0065000A"} {
+		_, err := ParseOptionSymbol(symbol)
+		assert.ErrorIs(t, err, ErrInvalidOptionSymbol, symbol)
+	}
+}
+
+func TestSubscribeOptionsChain(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+
+	contracts := []string{"O:SPY251219C00650000", "SPY251219P00600000", "O:QQQ251219C00500000", "garbage"}
+	assert.NoError(t, NewClient("test-token").SubscribeOptionsChain(conn, "spy", contracts, OptionEventTypeQ))
+	assert.Equal(t, `{"action":"subscribe","params":"Q.O:SPY251219C00650000,Q.O:SPY251219P00600000"}`, conn.written()[1])
+}
+
+func TestSubscribeOptionsTrades(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+
+	assert.NoError(t, NewClient("test-token").SubscribeOptionsTrades(conn, []string{"spy251219c00650000"}))
+	assert.Equal(t, "wss://business.polygon.io/options", conn.dialed())
+	assert.Equal(t, `{"action":"subscribe","params":"T.O:SPY251219C00650000"}`, conn.written()[1])
+}
+
+func TestOptionChannelsPrefixCase(t *testing.T) {
+	assert.Equal(t, []string{"T.O:SPY251219C00650000", "T.O:SPY251219C00650000", "T.*"},
+		OptionChannels(OptionEventTypeT, "o:spy251219c00650000", "O:SPY251219C00650000", "*"))
+}
@@ -0,0 +1,168 @@
+package polygon
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// PriceLevel a price and the size available at it
+type PriceLevel struct {
+	Price float64
+	Size  float64
+}
+
+// UnmarshalJSON decodes the [price, size] pairs of level 2 messages
+func (l *PriceLevel) UnmarshalJSON(b []byte) error {
+	var pair []float64
+	if err := json.Unmarshal(b, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("price level: expected [price, size], got %s", b)
+	}
+	l.Price, l.Size = pair[0], pair[1]
+	return nil
+}
+
+// MarshalJSON encodes the level as [price, size]
+func (l PriceLevel) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]float64{l.Price, l.Size})
+}
+
+// OrderBook local level 2 books of crypto pairs built from XL2 updates, one per pair and exchange,
+// safe for concurrent use
+type OrderBook struct {
+	mu    sync.RWMutex
+	books map[bookKey]*pairBook
+}
+
+type bookKey struct {
+	pair     string
+	exchange int
+}
+
+type pairBook struct {
+	bids      map[float64]float64
+	asks      map[float64]float64
+	timestamp int64
+}
+
+// NewOrderBook creates an empty order book
+func NewOrderBook() *OrderBook {
+	return &OrderBook{books: make(map[bookKey]*pairBook)}
+}
+
+// Attach feeds the book with the XL2 events of a crypto dispatcher
+func (o *OrderBook) Attach(d *Dispatcher) {
+	On(d, o.Apply)
+}
+
+// Apply applies an incremental update to the book of its pair and exchange:
+// a level with a zero size is removed, others are set
+func (o *OrderBook) Apply(update CryptoLevel2) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	key := bookKey{update.Pair, update.Exchange}
+	book, ok := o.books[key]
+	if !ok {
+		book = &pairBook{
+			bids: make(map[float64]float64),
+			asks: make(map[float64]float64),
+		}
+		o.books[key] = book
+	}
+
+	applyLevels(book.bids, update.Bids)
+	applyLevels(book.asks, update.Asks)
+	book.timestamp = max(book.timestamp, update.Timestamp)
+}
+
+func applyLevels(side map[float64]float64, levels []PriceLevel) {
+	for _, l := range levels {
+		if l.Size <= 0 {
+			delete(side, l.Price)
+			continue
+		}
+		side[l.Price] = l.Size
+	}
+}
+
+// Exchanges returns the exchanges with a book for a pair, sorted
+func (o *OrderBook) Exchanges(pair string) []int {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	var exchanges []int
+	for key := range o.books {
+		if key.pair == pair {
+			exchanges = append(exchanges, key.exchange)
+		}
+	}
+	slices.Sort(exchanges)
+	return exchanges
+}
+
+// Reset drops the book of a pair on an exchange, e.g. after a reconnection
+func (o *OrderBook) Reset(pair string, exchange int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.books, bookKey{pair, exchange})
+}
+
+// BestBid returns the highest bid of a pair on an exchange
+func (o *OrderBook) BestBid(pair string, exchange int) (PriceLevel, bool) {
+	bids, _ := o.Depth(pair, exchange, 1)
+	if len(bids) == 0 {
+		return PriceLevel{}, false
+	}
+	return bids[0], true
+}
+
+// BestAsk returns the lowest ask of a pair on an exchange
+func (o *OrderBook) BestAsk(pair string, exchange int) (PriceLevel, bool) {
+	_, asks := o.Depth(pair, exchange, 1)
+	if len(asks) == 0 {
+		return PriceLevel{}, false
+	}
+	return asks[0], true
+}
+
+// Spread returns the best ask minus the best bid of a pair on an exchange, false if either side is empty
+func (o *OrderBook) Spread(pair string, exchange int) (float64, bool) {
+	bids, asks := o.Depth(pair, exchange, 1)
+	if len(bids) == 0 || len(asks) == 0 {
+		return 0, false
+	}
+	return asks[0].Price - bids[0].Price, true
+}
+
+// Depth returns up to n levels of each side of a pair on an exchange, best first. Zero or less means every level.
+func (o *OrderBook) Depth(pair string, exchange, n int) (bids, asks []PriceLevel) {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+
+	book, ok := o.books[bookKey{pair, exchange}]
+	if !ok {
+		return nil, nil
+	}
+
+	bidPrices := slices.Sorted(maps.Keys(book.bids))
+	slices.Reverse(bidPrices)
+	return topLevels(book.bids, bidPrices, n), topLevels(book.asks, slices.Sorted(maps.Keys(book.asks)), n)
+}
+
+func topLevels(side map[float64]float64, prices []float64, n int) []PriceLevel {
+	if n > 0 && len(prices) > n {
+		prices = prices[:n]
+	}
+
+	levels := make([]PriceLevel, 0, len(prices))
+	for _, price := range prices {
+		levels = append(levels, PriceLevel{Price: price, Size: side[price]})
+	}
+	return levels
+}
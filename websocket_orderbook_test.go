@@ -0,0 +1,69 @@
+package polygon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecoderCryptoTradesAndQuotes(t *testing.T) {
+	frame := []byte(`[{"ev":"XT","pair":"BTC-USD","p":33021.9,"t":1610462007425,"s":0.01616617,"c":[2],"i":"14272084","x":1,"r":1610462007576},` +
+		`{"ev":"XQ","pair":"BTC-USD","bp":33052.79,"bs":0.48,"ap":33073.19,"as":1,"t":1610462411115,"x":1,"r":1610462411128},` +
+		`{"ev":"XL2","pair":"BTC-USD","t":1610462411115,"r":1610462411128,"x":12,"b":[[33712.7,0.18635]],"a":[[33718.23,3.5527483]]}]`)
+
+	events, err := NewDecoder(ClusterCrypto).Decode(frame)
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{
+		CryptoTrade{Event: CryptoEventTypeXT, Pair: "BTC-USD", Price: 33021.9, Size: 0.01616617, Conditions: []int{2}, ID: "14272084", Exchange: 1, Timestamp: 1610462007425, ReceivedTimestamp: 1610462007576},
+		CryptoQuote{Event: CryptoEventTypeXQ, Pair: "BTC-USD", BidPrice: 33052.79, BidSize: 0.48, AskPrice: 33073.19, AskSize: 1, Exchange: 1, Timestamp: 1610462411115, ReceivedTimestamp: 1610462411128},
+		CryptoLevel2{Event: CryptoEventTypeXL2, Pair: "BTC-USD", Bids: []PriceLevel{{33712.7, 0.18635}}, Asks: []PriceLevel{{33718.23, 3.5527483}}, Exchange: 12, Timestamp: 1610462411115, ReceivedTimestamp: 1610462411128},
+	}, events)
+
+	_, err = NewDecoder(ClusterCrypto).Decode([]byte(`{"ev":"XL2","pair":"BTC-USD","b":[[1]]}`))
+	assert.Error(t, err)
+}
+
+func TestOrderBook(t *testing.T) {
+	book := NewOrderBook()
+	d := NewDispatcher(ClusterCrypto)
+	book.Attach(d)
+
+	_, ok := book.Spread("BTC-USD", 1)
+	assert.False(t, ok)
+
+	d.HandleFrame([]byte(`{"ev":"XL2","pair":"BTC-USD","x":1,"b":[[100,1],[99,2],[98,3]],"a":[[101,1],[102,2]]}`))
+	d.HandleFrame([]byte(`{"ev":"XL2","pair":"BTC-USD","x":1,"b":[[100,0],[99.5,4]],"a":[[102,5]]}`))
+	d.HandleFrame([]byte(`{"ev":"XL2","pair":"BTC-USD","x":2,"b":[[97,7]],"a":[[103,1]]}`))
+	d.HandleFrame([]byte(`{"ev":"XL2","pair":"ETH-USD","x":1,"b":[[10,1]]}`))
+
+	bid, ok := book.BestBid("BTC-USD", 1)
+	assert.True(t, ok)
+	assert.Equal(t, PriceLevel{99.5, 4}, bid)
+
+	ask, ok := book.BestAsk("BTC-USD", 1)
+	assert.True(t, ok)
+	assert.Equal(t, PriceLevel{101, 1}, ask)
+
+	spread, ok := book.Spread("BTC-USD", 1)
+	assert.True(t, ok)
+	assert.InDelta(t, 1.5, spread, 1e-9)
+
+	bids, asks := book.Depth("BTC-USD", 1, 2)
+	assert.Equal(t, []PriceLevel{{99.5, 4}, {99, 2}}, bids)
+	assert.Equal(t, []PriceLevel{{101, 1}, {102, 5}}, asks)
+
+	// each exchange keeps its own book
+	assert.Equal(t, []int{1, 2}, book.Exchanges("BTC-USD"))
+	bids, asks = book.Depth("BTC-USD", 2, 0)
+	assert.Equal(t, []PriceLevel{{97, 7}}, bids)
+	assert.Equal(t, []PriceLevel{{103, 1}}, asks)
+
+	_, ok = book.Spread("ETH-USD", 1)
+	assert.False(t, ok)
+
+	book.Reset("BTC-USD", 1)
+	_, ok = book.BestBid("BTC-USD", 1)
+	assert.False(t, ok)
+	_, ok = book.BestBid("BTC-USD", 2)
+	assert.True(t, ok)
+}
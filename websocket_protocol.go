@@ -0,0 +1,163 @@
+package polygon
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// websocket actions
+const (
+	ActionAuth        = "auth"
+	ActionSubscribe   = "subscribe"
+	ActionUnsubscribe = "unsubscribe"
+)
+
+// defaultWebsocketFrameSize keeps subscription frames well under the message size accepted by polygon
+const defaultWebsocketFrameSize = 8 * 1024
+
+// WithWebsocketFrameSize sets the maximum size in bytes of subscription frames, longer channel lists
+// are split into several frames (default 8KiB)
+func WithWebsocketFrameSize(size int) ClientOption {
+	return func(client *Client) {
+		client.websocketFrameSize = size
+	}
+}
+
+// ProtocolEncoder encodes the actions of the polygon websocket protocol
+type ProtocolEncoder struct {
+	// MaxFrameSize is the maximum size in bytes of a subscription frame, zero or less means no limit
+	MaxFrameSize int
+}
+
+type protocolAction struct {
+	Action string `json:"action"`
+	Params string `json:"params"`
+}
+
+// Auth encodes the auth action
+func (e ProtocolEncoder) Auth(token string) ([]byte, error) {
+	return json.Marshal(protocolAction{Action: ActionAuth, Params: token})
+}
+
+// Subscribe encodes the subscribe action of channels such as "AM.AAPL", split into several frames if needed
+func (e ProtocolEncoder) Subscribe(channels []string) ([][]byte, error) {
+	return e.encodeChannels(ActionSubscribe, channels)
+}
+
+// Unsubscribe encodes the unsubscribe action of channels, split into several frames if needed
+func (e ProtocolEncoder) Unsubscribe(channels []string) ([][]byte, error) {
+	return e.encodeChannels(ActionUnsubscribe, channels)
+}
+
+// encodeChannels packs as many comma separated channels as fit in a frame,
+// a channel larger than the limit on its own is sent alone
+func (e ProtocolEncoder) encodeChannels(action string, channels []string) ([][]byte, error) {
+	if err := validateChannels(channels); err != nil {
+		return nil, err
+	}
+
+	empty, err := json.Marshal(protocolAction{Action: action})
+	if err != nil {
+		return nil, err
+	}
+
+	var frames [][]byte
+	var batch []string
+	size := len(empty)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		frame, err := json.Marshal(protocolAction{Action: action, Params: strings.Join(batch, ",")})
+		if err != nil {
+			return err
+		}
+		frames = append(frames, frame)
+		batch, size = nil, len(empty)
+		return nil
+	}
+
+	for _, channel := range channels {
+		quoted, err := json.Marshal(channel)
+		if err != nil {
+			return nil, err
+		}
+
+		// the escaped channel, plus the separating comma
+		grow := len(quoted) - 2
+		if len(batch) > 0 {
+			grow++
+		}
+
+		if e.MaxFrameSize > 0 && len(batch) > 0 && size+grow > e.MaxFrameSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			grow = len(quoted) - 2
+		}
+
+		batch = append(batch, channel)
+		size += grow
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return frames, nil
+}
+
+// protocolEncoder returns the encoder configured on the client
+func (c Client) protocolEncoder() ProtocolEncoder {
+	size := c.websocketFrameSize
+	if size == 0 {
+		size = defaultWebsocketFrameSize
+	}
+	return ProtocolEncoder{MaxFrameSize: size}
+}
+
+// subscribe connects to a cluster, authenticates and subscribes to channels, it is shared by the Subscribe functions.
+// When the client can read, it waits for the replies and returns a StatusError if a subscription is rejected.
+func (c Client) subscribe(client WebSocketClient, cluster Cluster, channels []string) error {
+	if err := CheckFeed(c.feed, cluster, channels...); err != nil {
+		return err
+	}
+
+	encoder := c.protocolEncoder()
+	subscribe, err := encoder.Subscribe(channels)
+	if err != nil {
+		return err
+	}
+
+	auth, err := encoder.Auth(c.token)
+	if err != nil {
+		return err
+	}
+
+	// connect
+	client.Dial(fmt.Sprintf("%s/%s", c.websocketBaseURL, cluster), nil)
+	// auth
+	if err := client.WriteMessage(TextMessage, auth); err != nil {
+		return err
+	}
+
+	// wait for the auth status reply when the client can read it
+	if reader, ok := client.(MessageReader); ok {
+		if err := c.awaitAuth(reader); err != nil {
+			return err
+		}
+	}
+
+	// subscribe
+	for _, frame := range subscribe {
+		if err := client.WriteMessage(TextMessage, frame); err != nil {
+			return err
+		}
+	}
+
+	// wait for the subscription replies when the client can read them
+	if reader, ok := client.(MessageReader); ok {
+		return c.awaitSubscriptions(reader, channels)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package polygon
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtocolEncoderAuthEscapes(t *testing.T) {
+	frame, err := ProtocolEncoder{}.Auth(`to"k\en`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"action":"auth","params":"to\"k\\en"}`, string(frame))
+
+	var action protocolAction
+	assert.NoError(t, json.Unmarshal(frame, &action))
+	assert.Equal(t, `to"k\en`, action.Params)
+}
+
+func TestProtocolEncoderSplitsChannels(t *testing.T) {
+	frames, err := ProtocolEncoder{}.Subscribe([]string{"AM.AAPL", "AM.MSFT"})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte(`{"action":"subscribe","params":"AM.AAPL,AM.MSFT"}`)}, frames)
+
+	channels := StockChannels(StockEventTypeT, strings.Fields("AAPL MSFT GOOG AMZN META NVDA TSLA")...)
+	encoder := ProtocolEncoder{MaxFrameSize: len(`{"action":"unsubscribe","params":"T.AAPL,T.MSFT,T.GOOG"}`)}
+	frames, err = encoder.Unsubscribe(channels)
+	assert.NoError(t, err)
+
+	var got []string
+	for _, frame := range frames {
+		assert.LessOrEqual(t, len(frame), encoder.MaxFrameSize)
+
+		var action protocolAction
+		assert.NoError(t, json.Unmarshal(frame, &action))
+		assert.Equal(t, ActionUnsubscribe, action.Action)
+		got = append(got, strings.Split(action.Params, ",")...)
+	}
+	assert.Equal(t, []string{
+		`{"action":"unsubscribe","params":"T.AAPL,T.MSFT,T.GOOG"}`,
+		`{"action":"unsubscribe","params":"T.AMZN,T.META,T.NVDA"}`,
+		`{"action":"unsubscribe","params":"T.TSLA"}`,
+	}, []string{string(frames[0]), string(frames[1]), string(frames[2])})
+	assert.Equal(t, channels, got)
+
+	// a channel larger than the limit is sent alone
+	frames, err = ProtocolEncoder{MaxFrameSize: 10}.Subscribe([]string{"AM.AAPL", "AM.MSFT"})
+	assert.NoError(t, err)
+	assert.Len(t, frames, 2)
+
+	_, err = ProtocolEncoder{}.Subscribe([]string{"AM.AAPL,AM.MSFT"})
+	assert.ErrorIs(t, err, ErrInvalidChannel)
+
+	frames, err = ProtocolEncoder{}.Subscribe(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, frames)
+}
+
+func TestSubscribeSplitsFrames(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+
+	client := NewClient(`test"token`, WithWebsocketFrameSize(len(`{"action":"subscribe","params":"AM.AAPL,AM.MSFT"}`)))
+	assert.NoError(t, client.SubscribeStockAggregates(conn, []string{"AAPL", "MSFT", "GOOG"}, StockEventTypeAM))
+	assert.Equal(t, []string{
+		`{"action":"auth","params":"test\"token"}`,
+		`{"action":"subscribe","params":"AM.AAPL,AM.MSFT"}`,
+		`{"action":"subscribe","params":"AM.GOOG"}`,
+	}, conn.written())
+}
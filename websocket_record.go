@@ -0,0 +1,149 @@
+package polygon
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// recordedFrame line of a recording, text frames are stored as a string and other frames base64 encoded,
+// so that they are replayed byte for byte
+type recordedFrame struct {
+	Time  int64  `json:"t"`              // receive timestamp in Unix Nanoseconds
+	Type  int    `json:"type,omitempty"` // message type, omitted for text messages
+	Text  string `json:"text,omitempty"`
+	Bytes []byte `json:"bytes,omitempty"`
+}
+
+// Recorder writes raw websocket frames with their receive timestamp as JSON lines, safe for concurrent use
+type Recorder struct {
+	mu     sync.Mutex
+	enc    *json.Encoder
+	gz     *gzip.Writer
+	closer io.Closer
+}
+
+// NewRecorder creates a recorder writing JSON lines to w, gzip compressed if compress is set
+func NewRecorder(w io.Writer, compress bool) *Recorder {
+	r := &Recorder{}
+	if compress {
+		r.gz = gzip.NewWriter(w)
+		w = r.gz
+	}
+	r.enc = json.NewEncoder(w)
+	r.enc.SetEscapeHTML(false)
+	return r
+}
+
+// CreateRecorder opens the file at path for appending, creating it if needed.
+// Frames are gzip compressed when the path ends with ".gz".
+func CreateRecorder(path string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	r := NewRecorder(f, strings.HasSuffix(path, ".gz"))
+	r.closer = f
+	return r, nil
+}
+
+// Record writes a frame received at t
+func (r *Recorder) Record(t time.Time, messageType int, data []byte) error {
+	frame := recordedFrame{Time: t.UnixNano()}
+	if messageType != TextMessage {
+		frame.Type = messageType
+	}
+	if messageType == TextMessage && utf8.Valid(data) {
+		frame.Text = string(data)
+	} else {
+		frame.Bytes = data
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.enc.Encode(frame)
+}
+
+// Flush flushes the compressed frames to the underlying writer
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.gz == nil {
+		return nil
+	}
+	return r.gz.Flush()
+}
+
+// Close flushes the recording, and closes the file opened by CreateRecorder
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+	if r.gz != nil {
+		err = r.gz.Close()
+	}
+	if r.closer != nil {
+		if cerr := r.closer.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// RecordConn wraps a connection so that every frame it reads is recorded, it can be passed to NewStream as is.
+// The wrapper is a ReadDeadliner when conn is one.
+func RecordConn(conn StreamConn, r *Recorder) StreamConn {
+	c := &recordingConn{conn: conn, recorder: r}
+	if d, ok := conn.(ReadDeadliner); ok {
+		return &deadlineRecordingConn{recordingConn: c, deadliner: d}
+	}
+	return c
+}
+
+type recordingConn struct {
+	conn     StreamConn
+	recorder *Recorder
+}
+
+// deadlineRecordingConn recordingConn forwarding read deadlines
+type deadlineRecordingConn struct {
+	*recordingConn
+	deadliner ReadDeadliner
+}
+
+func (c *deadlineRecordingConn) SetReadDeadline(t time.Time) error {
+	return c.deadliner.SetReadDeadline(t)
+}
+
+func (c *recordingConn) Dial(urlStr string, reqHeader http.Header) {
+	c.conn.Dial(urlStr, reqHeader)
+}
+
+func (c *recordingConn) WriteMessage(messageType int, data []byte) error {
+	return c.conn.WriteMessage(messageType, data)
+}
+
+// ReadMessage reads a frame and records it, a recording failure is returned as a read error
+func (c *recordingConn) ReadMessage() (int, []byte, error) {
+	messageType, data, err := c.conn.ReadMessage()
+	if err != nil {
+		return messageType, data, err
+	}
+
+	if err := c.recorder.Record(time.Now(), messageType, data); err != nil {
+		return messageType, data, err
+	}
+	return messageType, data, nil
+}
+
+func (c *recordingConn) Close() error {
+	return c.conn.Close()
+}
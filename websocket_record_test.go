@@ -0,0 +1,227 @@
+package polygon
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorderFormat(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRecorder(&buf, false)
+
+	at := time.Unix(1700000000, 5)
+	assert.NoError(t, r.Record(at, TextMessage, []byte(`[{"ev":"AM", "sym":"A&B<C>"}]`)))
+	assert.NoError(t, r.Record(at, TextMessage, []byte("not json")))
+	assert.NoError(t, r.Record(at, BinaryMessage, []byte("\xff\x00{}")))
+	assert.NoError(t, r.Close())
+
+	assert.Equal(t, `{"t":1700000000000000005,"text":"[{\"ev\":\"AM\", \"sym\":\"A&B<C>\"}]"}`+"\n"+
+		`{"t":1700000000000000005,"text":"not json"}`+"\n"+
+		`{"t":1700000000000000005,"type":2,"bytes":"/wB7fQ=="}`+"\n", buf.String())
+
+	p, err := NewReplayer(&buf)
+	assert.NoError(t, err)
+
+	messageType, data, err := p.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, TextMessage, messageType)
+	// text frames are replayed byte for byte, spaces and HTML characters included
+	assert.Equal(t, `[{"ev":"AM", "sym":"A&B<C>"}]`, string(data))
+
+	messageType, data, err = p.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, TextMessage, messageType)
+	assert.Equal(t, "not json", string(data))
+
+	// binary frames are replayed byte for byte
+	messageType, data, err = p.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, BinaryMessage, messageType)
+	assert.Equal(t, []byte("\xff\x00{}"), data)
+
+	_, _, err = p.ReadMessage()
+	assert.ErrorIs(t, err, ErrReplayFinished)
+	<-p.Done()
+}
+
+func TestRecordConnReadDeadline(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRecorder(&buf, false)
+
+	_, ok := RecordConn(newFakeStreamConn(), r).(ReadDeadliner)
+	assert.False(t, ok)
+
+	conn := &deadlineConn{fakeStreamConn: newFakeStreamConn()}
+	conn.Dial("", nil)
+	recorded := RecordConn(conn, r)
+	d, ok := recorded.(ReadDeadliner)
+	if assert.True(t, ok) {
+		deadline := time.Now().Add(time.Minute)
+		assert.NoError(t, d.SetReadDeadline(deadline))
+		assert.Equal(t, deadline, conn.deadline)
+	}
+
+	conn.push(`[]`)
+	_, data, err := recorded.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
+	assert.Contains(t, buf.String(), `"text":"[]"`)
+}
+
+// recordSession records a stream session through RecordConn into a file
+func recordSession(t *testing.T, path string) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+
+	recorder, err := CreateRecorder(path)
+	assert.NoError(t, err)
+
+	stream := NewClient("test-token").NewStream(RecordConn(conn, recorder), ClusterStocks, []string{"AM.AAPL"})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		stream.Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return stream.State() == StreamStateConnected }, time.Second, time.Millisecond)
+	conn.push(`[{"ev":"AM","sym":"AAPL","c":1}]`)
+	time.Sleep(20 * time.Millisecond)
+	conn.push(`[{"ev":"AM","sym":"AAPL","c":2}]`)
+	assert.Eventually(t, func() bool { _, ok := stream.Acknowledgement("AM.AAPL"); return ok }, time.Second, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	<-done
+	assert.NoError(t, recorder.Close())
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	for _, name := range []string{"session.jsonl", "session.jsonl.gz"} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			recordSession(t, path)
+
+			replayer, err := OpenReplayer(path)
+			assert.NoError(t, err)
+
+			// the replay goes through the same stream, decoding and dispatch path
+			d := NewDispatcher(ClusterStocks)
+			var closes []float64
+			On(d, func(a StockAggregate) { closes = append(closes, a.TickClose) })
+
+			stream := NewClient("test-token").NewStream(replayer, ClusterStocks, []string{"AM.AAPL"}, WithDispatcher(d))
+			assert.NoError(t, stream.Run(context.Background()))
+			assert.Equal(t, StreamStateClosed, stream.State())
+			assert.Equal(t, []float64{1, 2}, closes)
+			ack, ok := stream.Acknowledgement("AM.AAPL")
+			assert.True(t, ok)
+			assert.NoError(t, ack.Err)
+		})
+	}
+}
+
+func TestReplayTruncatedRecording(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRecorder(&buf, true)
+	at := time.Now()
+	assert.NoError(t, r.Record(at, TextMessage, []byte(`[{"ev":"status","status":"auth_success","message":"authenticated"}]`)))
+	assert.NoError(t, r.Record(at, TextMessage, []byte(`[{"ev":"AM","sym":"AAPL","c":1}]`)))
+	// a crash leaves the recording flushed but not closed, without the gzip footer
+	assert.NoError(t, r.Flush())
+
+	replayer, err := NewReplayer(bytes.NewReader(buf.Bytes()))
+	assert.NoError(t, err)
+
+	d := NewDispatcher(ClusterStocks)
+	var closes []float64
+	On(d, func(a StockAggregate) { closes = append(closes, a.TickClose) })
+
+	var states []StreamState
+	stream := NewClient("test-token").NewStream(replayer, ClusterStocks, nil, WithDispatcher(d),
+		WithStateHandler(func(state StreamState, err error) { states = append(states, state) }))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	assert.NoError(t, stream.Run(ctx))
+	assert.NoError(t, ctx.Err(), "the stream must stop on its own")
+	assert.NotContains(t, states, StreamStateReconnecting)
+	assert.Equal(t, []float64{1}, closes)
+	<-replayer.Done()
+}
+
+func TestReplayCorruptRecording(t *testing.T) {
+	replayer, err := NewReplayer(strings.NewReader(`{"t":1,"text":"[]"}` + "\n" + `not json` + "\n"))
+	assert.NoError(t, err)
+
+	stream := NewClient("test-token").NewStream(replayer, ClusterStocks, nil, WithReconnectBackoff(time.Millisecond, time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	assert.ErrorIs(t, stream.Run(ctx), ErrReplayFailed)
+	assert.NoError(t, ctx.Err(), "the stream must stop on its own")
+}
+
+func TestReplaySpeed(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewRecorder(&buf, true)
+	start := time.Now()
+	for i := range 3 {
+		r.Record(start.Add(time.Duration(i)*100*time.Millisecond), TextMessage, []byte(`[]`))
+	}
+	assert.NoError(t, r.Close())
+	recording := buf.Bytes()
+
+	replay := func(opts ...ReplayOption) time.Duration {
+		p, err := NewReplayer(bytes.NewReader(recording), opts...)
+		assert.NoError(t, err)
+
+		begin := time.Now()
+		for {
+			if _, _, err := p.ReadMessage(); err != nil {
+				assert.ErrorIs(t, err, ErrReplayFinished)
+				return time.Since(begin)
+			}
+		}
+	}
+
+	// waits are never shorter than the recorded gaps, faster replays finish first
+	realTime := replay(WithReplaySpeed(1))
+	fast := replay(WithReplaySpeed(4))
+	asap := replay()
+	assert.GreaterOrEqual(t, realTime, 200*time.Millisecond)
+	assert.GreaterOrEqual(t, fast, 50*time.Millisecond)
+	assert.Less(t, fast, realTime)
+	assert.Less(t, asap, fast)
+}
+
+func TestReplayClose(t *testing.T) {
+	recording := `{"t":1,"text":"[]"}` + "\n" + `{"t":60000000001,"text":"[]"}` + "\n"
+	p, err := NewReplayer(strings.NewReader(recording), WithReplaySpeed(1))
+	assert.NoError(t, err)
+
+	_, _, err = p.ReadMessage()
+	assert.NoError(t, err)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		p.Close()
+	}()
+	_, _, err = p.ReadMessage()
+	assert.Error(t, err)
+
+	// a new connection resumes the replay with the interrupted frame, without waiting
+	p.Dial("", nil)
+	_, data, err := p.ReadMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", string(data))
+
+	_, _, err = p.ReadMessage()
+	assert.ErrorIs(t, err, ErrReplayFinished)
+}
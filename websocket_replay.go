@@ -0,0 +1,192 @@
+package polygon
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrReplayFinished every frame of a recording has been replayed, a Stream stops when it reads it
+	ErrReplayFinished = errors.New("replay: finished")
+	// ErrReplayFailed the recording cannot be read any further, a Stream stops with it
+	ErrReplayFailed = errors.New("replay: failed")
+)
+
+// Replayer replays a recording as a StreamConn, so that frames go through the same decoding and dispatch path.
+// Writes are discarded: the recording already holds the authentication and subscription replies.
+type Replayer struct {
+	dec    *json.Decoder
+	closer io.Closer
+	speed  float64
+
+	mu       sync.Mutex
+	closed   chan struct{}
+	done     chan struct{}
+	finished bool
+	previous int64          // receive timestamp of the previous frame in Unix Nanoseconds
+	pending  *recordedFrame // frame whose wait was interrupted by Close
+}
+
+// ReplayOption applies an option to a Replayer.
+type ReplayOption func(*Replayer)
+
+// WithReplaySpeed replays frames at speed times their original pace, e.g. 1 for real time or 10 for 10x.
+// Zero or less, the default, replays as fast as possible.
+func WithReplaySpeed(speed float64) ReplayOption {
+	return func(r *Replayer) {
+		r.speed = speed
+	}
+}
+
+// NewReplayer creates a replayer reading a recording from r, gzip compressed recordings are detected
+func NewReplayer(r io.Reader, opts ...ReplayOption) (*Replayer, error) {
+	br := bufio.NewReader(r)
+	var src io.Reader = br
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		src = gz
+	}
+
+	p := &Replayer{
+		dec:    json.NewDecoder(src),
+		closed: make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	for _, applyOption := range opts {
+		applyOption(p)
+	}
+	return p, nil
+}
+
+// OpenReplayer creates a replayer reading the recording at path, the file is closed once replayed
+func OpenReplayer(path string, opts ...ReplayOption) (*Replayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := NewReplayer(f, opts...)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	p.closer = f
+	return p, nil
+}
+
+// Done is closed once every frame has been replayed
+func (p *Replayer) Done() <-chan struct{} {
+	return p.done
+}
+
+// Dial implements WebSocketClient, replay resumes where the previous connection stopped, without waiting
+func (p *Replayer) Dial(urlStr string, reqHeader http.Header) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.previous = 0
+	select {
+	case <-p.closed:
+		p.closed = make(chan struct{})
+	default:
+	}
+}
+
+// WriteMessage implements WebSocketClient, the message is discarded
+func (p *Replayer) WriteMessage(messageType int, data []byte) error {
+	return nil
+}
+
+// ReadMessage returns the next recorded frame, waiting for its turn when a replay speed is set.
+// It returns ErrReplayFinished after the last frame, or at a truncated tail such as the one of a gzip recording
+// that was never closed, ErrReplayFailed when the recording is corrupt, and net.ErrClosed once closed.
+// Like a websocket connection, it supports one reader at a time.
+func (p *Replayer) ReadMessage() (int, []byte, error) {
+	p.mu.Lock()
+	closed := p.closed
+	select {
+	case <-closed:
+		p.mu.Unlock()
+		return 0, nil, net.ErrClosed
+	default:
+	}
+
+	var frame recordedFrame
+	if p.pending != nil {
+		frame, p.pending = *p.pending, nil
+	} else if err := p.dec.Decode(&frame); err != nil {
+		p.finishLocked()
+		p.mu.Unlock()
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return 0, nil, ErrReplayFinished
+		}
+		return 0, nil, fmt.Errorf("%w: %w", ErrReplayFailed, err)
+	}
+
+	var wait time.Duration
+	if p.speed > 0 && p.previous != 0 && frame.Time > p.previous {
+		wait = time.Duration(float64(frame.Time-p.previous) / p.speed)
+	}
+	p.mu.Unlock()
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-closed:
+			timer.Stop()
+			p.mu.Lock()
+			p.pending = &frame
+			p.mu.Unlock()
+			return 0, nil, net.ErrClosed
+		}
+	}
+
+	p.mu.Lock()
+	p.previous = frame.Time
+	p.mu.Unlock()
+
+	messageType := frame.Type
+	if messageType == 0 {
+		messageType = TextMessage
+	}
+	if frame.Bytes != nil {
+		return messageType, frame.Bytes, nil
+	}
+	return messageType, []byte(frame.Text), nil
+}
+
+// Close implements StreamConn, it unblocks a pending ReadMessage
+func (p *Replayer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	select {
+	case <-p.closed:
+	default:
+		close(p.closed)
+	}
+	return nil
+}
+
+// finishLocked closes Done and the recording, it must be called with mu held
+func (p *Replayer) finishLocked() {
+	if p.finished {
+		return
+	}
+	p.finished = true
+	close(p.done)
+	if p.closer != nil {
+		p.closer.Close()
+	}
+}
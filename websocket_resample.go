@@ -0,0 +1,311 @@
+package polygon
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Bar OHLCV bar built by a Resampler
+type Bar struct {
+	FeedTag
+	Symbol string
+	Window time.Duration
+	Start  time.Time // wall-clock aligned start of the window, inclusive
+	End    time.Time // end of the window, exclusive
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+	VWAP   float64 // volume weighted average price, the close when there is no volume
+	Count  int     // number of aggregates in the bar
+	// Partial is set while the window is still filling, the last emission of a bar is final
+	Partial bool
+	// Amended is set when a late aggregate was added to a final bar, which is emitted again
+	Amended bool
+
+	notional float64 // sum of price * volume
+	openAt   int64   // start of the aggregates that set the open and the close, in Unix Milliseconds
+	closeAt  int64
+}
+
+// EventType implements Event
+func (b Bar) EventType() string { return "bar" }
+
+// EventSymbol implements Event
+func (b Bar) EventSymbol() string { return b.Symbol }
+
+// LatePolicy handling of aggregates older than the current bar of their symbol
+type LatePolicy int
+
+const (
+	// LateDrop ignores late aggregates
+	LateDrop LatePolicy = iota
+	// LateAmend adds a late aggregate to the last final bar if it belongs to it and emits the bar again with Amended set,
+	// older aggregates are ignored
+	LateAmend
+)
+
+// ErrInvalidWindow the window of a Resampler is not a positive multiple of a second
+var ErrInvalidWindow = errors.New("resample: window must be a positive multiple of a second")
+
+// Resampler builds bars of a window from second aggregates (A and XAS), safe for concurrent use.
+// Windows are aligned to wall-clock boundaries in America/New_York, counted from midnight,
+// so that e.g. 30 minute bars start at 9:30. A bar is final once an aggregate of a later window arrives, or on Flush.
+type Resampler struct {
+	window   time.Duration
+	location *time.Location
+	late     LatePolicy
+	partial  bool
+	onBar    func(Bar)
+
+	mu      sync.Mutex
+	current map[string]*Bar
+	last    map[string]*Bar
+	dropped uint64
+}
+
+// ResamplerOption applies an option to a Resampler.
+type ResamplerOption func(*Resampler)
+
+// WithLatePolicy sets the handling of late aggregates (default LateDrop)
+func WithLatePolicy(policy LatePolicy) ResamplerOption {
+	return func(r *Resampler) {
+		r.late = policy
+	}
+}
+
+// WithPartialBars emits a partial bar on every aggregate while the window fills (default true)
+func WithPartialBars(enabled bool) ResamplerOption {
+	return func(r *Resampler) {
+		r.partial = enabled
+	}
+}
+
+// WithResampleLocation aligns windows in the given location instead of America/New_York
+func WithResampleLocation(loc *time.Location) ResamplerOption {
+	return func(r *Resampler) {
+		r.location = loc
+	}
+}
+
+// NewResampler creates a resampler emitting bars of the given window, e.g. time.Minute or 15*time.Minute, to onBar
+func NewResampler(window time.Duration, onBar func(Bar), opts ...ResamplerOption) (*Resampler, error) {
+	if window < time.Second || window%time.Second != 0 {
+		return nil, ErrInvalidWindow
+	}
+
+	r := &Resampler{
+		window:  window,
+		partial: true,
+		onBar:   onBar,
+		current: make(map[string]*Bar),
+		last:    make(map[string]*Bar),
+	}
+	for _, applyOption := range opts {
+		applyOption(r)
+	}
+
+	if r.location == nil {
+		loc, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			return nil, err
+		}
+		r.location = loc
+	}
+
+	return r, nil
+}
+
+// Attach feeds the resampler with the second aggregates (A and XAS) of a dispatcher
+func (r *Resampler) Attach(d *Dispatcher) {
+	On(d, func(a StockAggregate) {
+		if a.Event == StockEventTypeA {
+			r.Add(a)
+		}
+	})
+	On(d, func(a CryptoAggregate) {
+		if a.Event == CryptoEventTypeXAS {
+			r.Add(a)
+		}
+	})
+}
+
+// Add adds a StockAggregate or a CryptoAggregate, it returns false for other events and dropped late aggregates
+func (r *Resampler) Add(e Event) bool {
+	var agg resampleInput
+	switch a := e.(type) {
+	case StockAggregate:
+		agg = resampleInput{a.FeedTag, a.Symbol, a.StartTimestamp, a.TickOpen, a.TickHigh, a.TickLow, a.TickClose, a.TickVolume, a.TickVWAP}
+	case CryptoAggregate:
+		agg = resampleInput{a.FeedTag, a.Pair, a.StartTimestamp, a.TickOpen, a.TickHigh, a.TickLow, a.TickClose, a.TickVolume, a.TickVWAP}
+	default:
+		return false
+	}
+
+	r.mu.Lock()
+	emit, ok := r.add(agg)
+	r.mu.Unlock()
+
+	for _, b := range emit {
+		r.onBar(b)
+	}
+	return ok
+}
+
+// Flush emits the bars being filled as final, e.g. at the end of the session
+func (r *Resampler) Flush() {
+	r.mu.Lock()
+	var emit []Bar
+	for symbol, b := range r.current {
+		b.Partial = false
+		emit = append(emit, *b)
+		r.last[symbol] = b
+		delete(r.current, symbol)
+	}
+	r.mu.Unlock()
+
+	for _, b := range emit {
+		r.onBar(b)
+	}
+}
+
+// Dropped returns the number of late aggregates that were ignored
+func (r *Resampler) Dropped() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.dropped
+}
+
+type resampleInput struct {
+	FeedTag
+	symbol                         string
+	start                          int64
+	open, high, low, close, volume float64
+	vwap                           float64
+}
+
+// add must be called with mu held, it returns the bars to emit
+func (r *Resampler) add(agg resampleInput) ([]Bar, bool) {
+	start, end := r.align(time.UnixMilli(agg.start))
+	current := r.current[agg.symbol]
+
+	switch {
+	case current == nil || start.After(current.Start):
+		var emit []Bar
+		if current != nil {
+			current.Partial = false
+			emit = append(emit, *current)
+			r.last[agg.symbol] = current
+		}
+
+		b := &Bar{FeedTag: agg.FeedTag, Symbol: agg.symbol, Window: r.window, Start: start, End: end, Partial: true}
+		b.apply(agg)
+		r.current[agg.symbol] = b
+		if r.partial {
+			emit = append(emit, *b)
+		}
+		return emit, true
+
+	case start.Equal(current.Start):
+		current.apply(agg)
+		if r.partial {
+			return []Bar{*current}, true
+		}
+		return nil, true
+	}
+
+	// late aggregate
+	if last := r.last[agg.symbol]; r.late == LateAmend && last != nil && start.Equal(last.Start) {
+		last.apply(agg)
+		last.Amended = true
+		return []Bar{*last}, true
+	}
+
+	r.dropped++
+	return nil, false
+}
+
+// align returns the window containing t. Windows start at midnight and every window of wall-clock time
+// after it in the resampler location. Boundaries are taken in absolute time within each UTC offset of the day,
+// so the windows of a day the clocks change still follow each other without gap nor overlap.
+func (r *Resampler) align(t time.Time) (start, end time.Time) {
+	t = t.In(r.location)
+	year, month, day := t.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, r.location)
+	next := time.Date(year, month, day+1, 0, 0, 0, 0, r.location)
+	return r.floorBoundary(t, midnight), r.ceilBoundary(t, next)
+}
+
+// floorBoundary returns the last window boundary at or before t, from at the earliest
+func (r *Resampler) floorBoundary(t, from time.Time) time.Time {
+	for {
+		b := t.Add(-(wallClock(t) % r.window))
+		zoneStart, _ := t.ZoneBounds()
+		if !b.Before(zoneStart) || !zoneStart.After(from) {
+			if b.Before(from) {
+				return from
+			}
+			return b
+		}
+		// no boundary since the clocks moved, look before the change
+		t = zoneStart.Add(-time.Nanosecond)
+	}
+}
+
+// ceilBoundary returns the first window boundary after t, to at the latest
+func (r *Resampler) ceilBoundary(t, to time.Time) time.Time {
+	for {
+		b := t.Add(r.window - wallClock(t)%r.window)
+		_, zoneEnd := t.ZoneBounds()
+		if zoneEnd.IsZero() || b.Before(zoneEnd) || !zoneEnd.Before(to) {
+			if b.After(to) {
+				return to
+			}
+			return b
+		}
+		// the clocks move first, look after the change
+		if wallClock(zoneEnd)%r.window == 0 {
+			return zoneEnd
+		}
+		t = zoneEnd
+	}
+}
+
+// wallClock returns the wall-clock time elapsed since midnight
+func wallClock(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+}
+
+func (b *Bar) apply(agg resampleInput) {
+	if b.Count == 0 {
+		b.Open, b.High, b.Low, b.Close = agg.open, agg.high, agg.low, agg.close
+		b.openAt, b.closeAt = agg.start, agg.start
+	}
+	b.High = max(b.High, agg.high)
+	b.Low = min(b.Low, agg.low)
+
+	// aggregates may arrive out of order within a window
+	if agg.start < b.openAt {
+		b.Open, b.openAt = agg.open, agg.start
+	}
+	if agg.start >= b.closeAt {
+		b.Close, b.closeAt = agg.close, agg.start
+	}
+
+	price := agg.vwap
+	if price == 0 {
+		price = agg.close
+	}
+	b.Volume += agg.volume
+	b.notional += price * agg.volume
+	b.Count++
+
+	if b.Volume > 0 {
+		b.VWAP = b.notional / b.Volume
+	} else {
+		b.VWAP = b.Close
+	}
+}
@@ -0,0 +1,235 @@
+package polygon
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newYork(t *testing.T) *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	return loc
+}
+
+func TestResamplerInvalidWindow(t *testing.T) {
+	for _, window := range []time.Duration{0, -time.Minute, 500 * time.Millisecond, 1500 * time.Millisecond} {
+		_, err := NewResampler(window, func(Bar) {})
+		assert.ErrorIs(t, err, ErrInvalidWindow, window)
+	}
+}
+
+func TestResamplerAlign(t *testing.T) {
+	loc := newYork(t)
+	r, err := NewResampler(30*time.Minute, func(Bar) {})
+	assert.NoError(t, err)
+
+	start, end := r.align(time.Date(2024, 3, 11, 9, 47, 12, 0, loc))
+	assert.Equal(t, time.Date(2024, 3, 11, 9, 30, 0, 0, loc), start)
+	assert.Equal(t, time.Date(2024, 3, 11, 10, 0, 0, 0, loc), end)
+
+	// wall-clock alignment on the day clocks move forward
+	r, _ = NewResampler(time.Hour, func(Bar) {})
+	start, end = r.align(time.Date(2024, 3, 10, 9, 47, 0, 0, loc))
+	assert.Equal(t, time.Date(2024, 3, 10, 9, 0, 0, 0, loc), start)
+	assert.Equal(t, time.Date(2024, 3, 10, 10, 0, 0, 0, loc), end)
+
+	// a window spanning the jump forward ends at its wall-clock boundary
+	r, _ = NewResampler(4*time.Hour, func(Bar) {})
+	start, end = r.align(time.Date(2024, 3, 10, 6, 30, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 3, 10, 5, 0, 0, 0, time.UTC).In(loc), start)
+	assert.Equal(t, time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC).In(loc), end)
+
+	// the repeated hour of the day clocks fall back gets its own windows,
+	// 05:30Z is 01:30 EDT and 06:30Z is 01:30 EST
+	r, _ = NewResampler(30*time.Minute, func(Bar) {})
+	start, end = r.align(time.Date(2024, 11, 3, 5, 59, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC).In(loc), start)
+	assert.Equal(t, time.Date(2024, 11, 3, 6, 0, 0, 0, time.UTC).In(loc), end)
+	start, end = r.align(time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC).In(loc), start)
+	assert.Equal(t, time.Date(2024, 11, 3, 7, 0, 0, 0, time.UTC).In(loc), end)
+
+	// a window spanning the fall back ends at its wall-clock boundary
+	r, _ = NewResampler(3*time.Hour, func(Bar) {})
+	start, end = r.align(time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2024, 11, 3, 4, 0, 0, 0, time.UTC).In(loc), start)
+	assert.Equal(t, time.Date(2024, 11, 3, 8, 0, 0, 0, time.UTC).In(loc), end)
+
+	// the last window of the day ends at midnight
+	r, _ = NewResampler(7*time.Hour, func(Bar) {})
+	start, end = r.align(time.Date(2024, 3, 11, 23, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2024, 3, 11, 21, 0, 0, 0, loc), start)
+	assert.Equal(t, time.Date(2024, 3, 12, 0, 0, 0, 0, loc), end)
+}
+
+func TestResamplerAlignClockChanges(t *testing.T) {
+	loc := newYork(t)
+	utc := func(day, hour, minute int) time.Time {
+		month := time.March
+		if day == 3 {
+			month = time.November
+		}
+		return time.Date(2024, month, day, hour, minute, 0, 0, time.UTC).In(loc)
+	}
+
+	cases := []struct {
+		window     time.Duration
+		t          time.Time
+		start, end time.Time
+	}{
+		// 01:10 EST is in the window of 01:30 EDT, the next one starts at 01:30 EST
+		{90 * time.Minute, utc(3, 6, 10), utc(3, 5, 30), utc(3, 6, 30)},
+		{90 * time.Minute, utc(3, 6, 40), utc(3, 6, 30), utc(3, 8, 0)},
+		// the window of 01:59 EST ends at 03:02 EDT, the next boundary, which starts the next one
+		{7 * time.Minute, utc(10, 6, 58), utc(10, 6, 52), utc(10, 6, 59)},
+		{7 * time.Minute, utc(10, 7, 1), utc(10, 6, 59), utc(10, 7, 2)},
+		{7 * time.Minute, utc(10, 7, 2), utc(10, 7, 2), utc(10, 7, 9)},
+		{2 * time.Hour, utc(10, 7, 30), utc(10, 5, 0), utc(10, 8, 0)},
+		{2 * time.Hour, utc(3, 6, 30), utc(3, 4, 0), utc(3, 7, 0)},
+	}
+	for _, tc := range cases {
+		r, _ := NewResampler(tc.window, func(Bar) {})
+		start, end := r.align(tc.t)
+		assert.Equal(t, tc.start, start, "%v %v", tc.window, tc.t)
+		assert.Equal(t, tc.end, end, "%v %v", tc.window, tc.t)
+	}
+
+	// windows contain their timestamps and follow each other without gap nor overlap
+	for _, window := range []time.Duration{7 * time.Minute, 90 * time.Minute, 2 * time.Hour} {
+		r, _ := NewResampler(window, func(Bar) {})
+		for _, day := range []time.Time{time.Date(2024, 3, 10, 0, 0, 0, 0, loc), time.Date(2024, 11, 3, 0, 0, 0, 0, loc)} {
+			for at := day.Add(-time.Hour); at.Before(day.Add(25 * time.Hour)); at = at.Add(time.Minute + 7*time.Second) {
+				start, end := r.align(at)
+				if !assert.True(t, !at.Before(start) && at.Before(end), "%v %v: [%v, %v)", window, at, start, end) {
+					break
+				}
+				next, _ := r.align(end)
+				_, previous := r.align(start.Add(-time.Nanosecond))
+				assert.Equal(t, end, next, "%v %v", window, at)
+				assert.Equal(t, start, previous, "%v %v", window, at)
+			}
+		}
+	}
+}
+
+func TestResamplerCryptoAcrossClockChanges(t *testing.T) {
+	loc := newYork(t)
+	for _, window := range []time.Duration{7 * time.Minute, 90 * time.Minute, 2 * time.Hour} {
+		for _, day := range []time.Time{time.Date(2024, 3, 10, 0, 0, 0, 0, loc), time.Date(2024, 11, 3, 0, 0, 0, 0, loc)} {
+			var bars []Bar
+			r, _ := NewResampler(window, func(b Bar) { bars = append(bars, b) }, WithPartialBars(false))
+			for at := day; at.Before(day.AddDate(0, 0, 1)); at = at.Add(time.Minute) {
+				r.Add(CryptoAggregate{Event: CryptoEventTypeXAS, Pair: "BTC-USD", TickOpen: 1, TickHigh: 1, TickLow: 1, TickClose: 1, TickVolume: 1,
+					StartTimestamp: at.UnixMilli()})
+			}
+			r.Flush()
+
+			assert.Zero(t, r.Dropped(), "%v %v", window, day)
+			var volume float64
+			for i, b := range bars {
+				volume += b.Volume
+				if i > 0 {
+					assert.Equal(t, bars[i-1].End, b.Start, "%v %v", window, b.Start)
+				}
+			}
+			assert.Equal(t, day.AddDate(0, 0, 1).Sub(day).Minutes(), volume, "%v %v", window, day)
+		}
+	}
+}
+
+func TestResamplerBars(t *testing.T) {
+	loc := newYork(t)
+	open := time.Date(2024, 3, 11, 9, 30, 0, 0, loc)
+	second := func(n int) int64 { return open.Add(time.Duration(n) * time.Second).UnixMilli() }
+
+	var bars []Bar
+	r, err := NewResampler(time.Minute, func(b Bar) { bars = append(bars, b) })
+	assert.NoError(t, err)
+
+	d := NewFeedDispatcher(FeedRealTime, ClusterStocks)
+	r.Attach(d)
+
+	d.HandleFrame([]byte(`{"ev":"A","sym":"AAPL","o":10,"h":11,"l":9.5,"c":10.5,"v":100,"vw":10.2,"s":` + itoa(second(0)) + `}`))
+	// minute aggregates are ignored
+	d.HandleFrame([]byte(`{"ev":"AM","sym":"AAPL","o":1,"h":100,"l":1,"c":1,"v":1000,"s":` + itoa(second(0)) + `}`))
+	d.HandleFrame([]byte(`{"ev":"A","sym":"AAPL","o":10.5,"h":12,"l":10,"c":11,"v":300,"vw":11.4,"s":` + itoa(second(30)) + `}`))
+	d.HandleFrame([]byte(`{"ev":"A","sym":"AAPL","o":11,"h":11,"l":10.8,"c":10.9,"v":50,"vw":10.9,"s":` + itoa(second(61)) + `}`))
+
+	if !assert.Len(t, bars, 4) {
+		return
+	}
+	assert.True(t, bars[0].Partial)
+	assert.True(t, bars[1].Partial)
+
+	final := bars[2]
+	assert.False(t, final.Partial)
+	assert.Equal(t, FeedRealTime, final.EventFeed())
+	assert.Equal(t, "AAPL", final.Symbol)
+	assert.Equal(t, open, final.Start)
+	assert.Equal(t, open.Add(time.Minute), final.End)
+	assert.Equal(t, []float64{10, 12, 9.5, 11, 400}, []float64{final.Open, final.High, final.Low, final.Close, final.Volume})
+	assert.InDelta(t, (10.2*100+11.4*300)/400, final.VWAP, 1e-9)
+	assert.Equal(t, 2, final.Count)
+
+	assert.True(t, bars[3].Partial)
+	assert.Equal(t, open.Add(time.Minute), bars[3].Start)
+
+	bars = nil
+	r.Flush()
+	if assert.Len(t, bars, 1) {
+		assert.False(t, bars[0].Partial)
+		assert.Equal(t, 10.9, bars[0].Close)
+	}
+}
+
+func TestResamplerLateEvents(t *testing.T) {
+	loc := newYork(t)
+	open := time.Date(2024, 3, 11, 9, 30, 0, 0, loc)
+	agg := func(n int, price, volume float64) CryptoAggregate {
+		return CryptoAggregate{Event: CryptoEventTypeXAS, Pair: "BTC-USD", TickOpen: price, TickHigh: price, TickLow: price, TickClose: price, TickVolume: volume, TickVWAP: price,
+			StartTimestamp: open.Add(time.Duration(n) * time.Second).UnixMilli()}
+	}
+
+	var bars []Bar
+	r, _ := NewResampler(time.Minute, func(b Bar) { bars = append(bars, b) }, WithPartialBars(false))
+	assert.True(t, r.Add(agg(10, 100, 1)))
+	assert.True(t, r.Add(agg(70, 110, 1)))
+	assert.False(t, r.Add(agg(20, 90, 1)))
+	assert.Equal(t, uint64(1), r.Dropped())
+	assert.False(t, r.Add(StockTrade{}))
+	if assert.Len(t, bars, 1) {
+		assert.Equal(t, 100.0, bars[0].Low)
+	}
+
+	bars = nil
+	r, _ = NewResampler(time.Minute, func(b Bar) { bars = append(bars, b) }, WithPartialBars(false), WithLatePolicy(LateAmend))
+	r.Add(agg(10, 100, 1))
+	r.Add(agg(70, 110, 1))
+	// out of order within the current window does not move the close
+	r.Add(agg(65, 105, 1))
+	assert.True(t, r.Add(agg(5, 90, 3)))
+	// too late to amend
+	assert.False(t, r.Add(agg(-60, 80, 1)))
+
+	if assert.Len(t, bars, 2) {
+		amended := bars[1]
+		assert.True(t, amended.Amended)
+		assert.Equal(t, open, amended.Start)
+		assert.Equal(t, []float64{90, 100, 90, 100, 4}, []float64{amended.Open, amended.High, amended.Low, amended.Close, amended.Volume})
+		assert.InDelta(t, (100+90*3)/4.0, amended.VWAP, 1e-9)
+	}
+
+	bars = nil
+	r.Flush()
+	if assert.Len(t, bars, 1) {
+		assert.Equal(t, 105.0, bars[0].Open)
+		assert.Equal(t, 110.0, bars[0].Close)
+	}
+}
+
+func itoa(n int64) string { return strconv.FormatInt(n, 10) }
@@ -0,0 +1,237 @@
+package polygon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const defaultWebsocketStatusTimeout = 10 * time.Second
+
+var (
+	// ErrAuthFailed the websocket authentication was rejected
+	ErrAuthFailed = errors.New("websocket: authentication failed")
+	// ErrMaxConnections the plan does not allow more websocket connections
+	ErrMaxConnections = errors.New("websocket: maximum number of connections exceeded")
+	// ErrUnknownChannel a subscription was rejected
+	ErrUnknownChannel = errors.New("websocket: unknown channel")
+	// ErrStatusTimeout no status reply was received in time
+	ErrStatusTimeout = errors.New("websocket: timed out waiting for status")
+)
+
+// Websocket status values sent by polygon
+const (
+	WebsocketStatusConnected      = "connected"
+	WebsocketStatusAuthSuccess    = "auth_success"
+	WebsocketStatusAuthFailed     = "auth_failed"
+	WebsocketStatusMaxConnections = "max_connections"
+	WebsocketStatusSuccess        = "success"
+	WebsocketStatusError          = "error"
+)
+
+// StatusError failure status reply, matched with errors.Is against ErrAuthFailed, ErrMaxConnections and ErrUnknownChannel
+type StatusError struct {
+	Status  string
+	Message string
+}
+
+// Error implements the error interface
+func (e StatusError) Error() string {
+	return fmt.Sprintf("websocket status %s: %s", e.Status, e.Message)
+}
+
+// Is implements errors.Is
+func (e StatusError) Is(target error) bool {
+	switch target {
+	case ErrAuthFailed:
+		return e.Status == WebsocketStatusAuthFailed
+	case ErrMaxConnections:
+		return e.Status == WebsocketStatusMaxConnections
+	case ErrUnknownChannel:
+		return e.Status == WebsocketStatusError
+	}
+	return false
+}
+
+// Err returns the error carried by a failure status, nil otherwise
+func (m StatusMessage) Err() error {
+	switch m.Status {
+	case WebsocketStatusAuthFailed, WebsocketStatusMaxConnections, WebsocketStatusError:
+		return StatusError{Status: m.Status, Message: m.Message}
+	}
+	return nil
+}
+
+// SubscriptionAck acknowledgement of a subscribe or unsubscribe action for a channel
+type SubscriptionAck struct {
+	// Action is "subscribe" or "unsubscribe"
+	Action  string
+	Channel string
+	// Err is set when the action was rejected, Channel may then be empty as polygon does not always name it
+	Err error
+}
+
+// subscriptionAck parses replies such as "subscribed to: AM.AAPL"
+func (m StatusMessage) subscriptionAck() (SubscriptionAck, bool) {
+	switch m.Status {
+	case WebsocketStatusSuccess:
+		if channel, ok := strings.CutPrefix(m.Message, "subscribed to: "); ok {
+			return SubscriptionAck{Action: "subscribe", Channel: strings.TrimSpace(channel)}, true
+		}
+		if channel, ok := strings.CutPrefix(m.Message, "unsubscribed to: "); ok {
+			return SubscriptionAck{Action: "unsubscribe", Channel: strings.TrimSpace(channel)}, true
+		}
+	case WebsocketStatusError:
+		return SubscriptionAck{Action: "subscribe", Err: m.Err()}, true
+	}
+	return SubscriptionAck{}, false
+}
+
+// MessageReader is implemented by websocket clients able to read messages
+type MessageReader interface {
+	ReadMessage() (messageType int, data []byte, err error)
+}
+
+// ReadDeadliner is implemented by websocket clients supporting read deadlines, e.g. gorilla/websocket
+type ReadDeadliner interface {
+	SetReadDeadline(t time.Time) error
+}
+
+// WithWebsocketStatusTimeout sets how long subscriptions wait for the authentication status reply,
+// then for the subscription replies (default 10s)
+func WithWebsocketStatusTimeout(timeout time.Duration) ClientOption {
+	return func(client *Client) {
+		client.websocketStatusTimeout = timeout
+	}
+}
+
+// WithWebsocketSubscriptionHandler sets the handler called with the acknowledgement of every channel
+// subscribed by the Subscribe functions
+func WithWebsocketSubscriptionHandler(fn func(SubscriptionAck)) ClientOption {
+	return func(client *Client) {
+		client.websocketSubscriptionHandler = fn
+	}
+}
+
+// statusMessages extracts the status messages of a frame
+func statusMessages(frame []byte) []StatusMessage {
+	if !bytes.Contains(frame, []byte(`"status"`)) {
+		return nil
+	}
+
+	events, _ := NewDecoder("").Decode(frame)
+	var messages []StatusMessage
+	for _, e := range events {
+		if m, ok := e.(StatusMessage); ok {
+			messages = append(messages, m)
+		}
+	}
+	return messages
+}
+
+// awaitAuth reads frames until the authentication status reply arrives or the timeout expires
+func (c Client) awaitAuth(conn MessageReader) error {
+	return c.awaitStatus(conn, func(m StatusMessage) (bool, error) {
+		if m.Status == WebsocketStatusAuthSuccess {
+			return true, nil
+		}
+		return false, m.Err()
+	})
+}
+
+// awaitSubscriptions reads frames until every channel is acknowledged, a subscription is rejected
+// or the timeout expires. Acknowledgements are passed to the subscription handler of the client.
+func (c Client) awaitSubscriptions(conn MessageReader, channels []string) error {
+	waiting := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		waiting[channel] = true
+	}
+	if len(waiting) == 0 {
+		return nil
+	}
+
+	return c.awaitStatus(conn, func(m StatusMessage) (bool, error) {
+		ack, ok := m.subscriptionAck()
+		if !ok || ack.Action != ActionSubscribe {
+			return false, m.Err()
+		}
+		if ack.Err == nil && !waiting[ack.Channel] {
+			return false, nil
+		}
+
+		delete(waiting, ack.Channel)
+		if c.websocketSubscriptionHandler != nil {
+			c.websocketSubscriptionHandler(ack)
+		}
+		return len(waiting) == 0, ack.Err
+	})
+}
+
+// awaitStatus reads frames and passes their status messages to handle until it is done, fails or the timeout expires.
+// Without read deadlines the reads run aside, and on timeout the connection is closed when possible
+// so that the pending read fails instead of consuming a later frame.
+func (c Client) awaitStatus(conn MessageReader, handle func(StatusMessage) (done bool, err error)) error {
+	timeout := c.websocketStatusTimeout
+	if timeout <= 0 {
+		timeout = defaultWebsocketStatusTimeout
+	}
+	deadline := time.Now().Add(timeout)
+
+	read := func() ([]byte, error) {
+		_, data, err := conn.ReadMessage()
+		return data, err
+	}
+
+	if d, ok := conn.(ReadDeadliner); ok {
+		if err := d.SetReadDeadline(deadline); err != nil {
+			return err
+		}
+		defer d.SetReadDeadline(time.Time{})
+	} else {
+		read = func() ([]byte, error) {
+			type frame struct {
+				data []byte
+				err  error
+			}
+
+			ch := make(chan frame, 1)
+			go func() {
+				_, data, err := conn.ReadMessage()
+				ch <- frame{data, err}
+			}()
+
+			select {
+			case f := <-ch:
+				return f.data, f.err
+			case <-time.After(time.Until(deadline)):
+				if closer, ok := conn.(io.Closer); ok {
+					closer.Close()
+				}
+				return nil, ErrStatusTimeout
+			}
+		}
+	}
+
+	for {
+		data, err := read()
+		if err != nil {
+			if time.Now().After(deadline) {
+				return ErrStatusTimeout
+			}
+			return err
+		}
+
+		for _, m := range statusMessages(data) {
+			done, err := handle(m)
+			if err != nil {
+				return err
+			}
+			if done {
+				return nil
+			}
+		}
+	}
+}
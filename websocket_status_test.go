@@ -0,0 +1,172 @@
+package polygon
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusMessageErr(t *testing.T) {
+	assert.NoError(t, StatusMessage{Status: WebsocketStatusAuthSuccess}.Err())
+	assert.ErrorIs(t, StatusMessage{Status: WebsocketStatusAuthFailed, Message: "authentication failed"}.Err(), ErrAuthFailed)
+	assert.ErrorIs(t, StatusMessage{Status: WebsocketStatusMaxConnections}.Err(), ErrMaxConnections)
+	assert.ErrorIs(t, StatusMessage{Status: WebsocketStatusError, Message: "unknown channel"}.Err(), ErrUnknownChannel)
+}
+
+func TestSubscribeAuthFailed(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = func(f *fakeStreamConn, msg string) {
+		f.push(`[{"ev":"status","status":"connected","message":"Connected Successfully"}]`)
+		f.push(`[{"ev":"status","status":"auth_failed","message":"authentication failed"}]`)
+	}
+
+	err := NewClient("bad-token").SubscribeStockAggregates(conn, []string{"AAPL"}, StockEventTypeAM)
+	assert.ErrorIs(t, err, ErrAuthFailed)
+	assert.Len(t, conn.written(), 1, "must not subscribe after a failed auth")
+}
+
+func TestSubscribeAcknowledgements(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+
+	var acks []SubscriptionAck
+	client := NewClient("test-token", WithWebsocketSubscriptionHandler(func(ack SubscriptionAck) { acks = append(acks, ack) }))
+	assert.NoError(t, client.SubscribeStockAggregates(conn, []string{"AAPL", "MSFT"}, StockEventTypeAM))
+	assert.Equal(t, []SubscriptionAck{
+		{Action: "subscribe", Channel: "AM.AAPL"},
+		{Action: "subscribe", Channel: "AM.MSFT"},
+	}, acks)
+}
+
+func TestSubscribeRejected(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = func(f *fakeStreamConn, msg string) {
+		if msg == `{"action":"auth","params":"test-token"}` {
+			polygonReplies(f, msg)
+			return
+		}
+		f.push(`[{"ev":"status","status":"success","message":"subscribed to: AM.AAPL"}]`)
+		f.push(`[{"ev":"status","status":"error","message":"unknown channel: AM.ZZZZ"}]`)
+	}
+
+	var acks []SubscriptionAck
+	client := NewClient("test-token", WithWebsocketSubscriptionHandler(func(ack SubscriptionAck) { acks = append(acks, ack) }))
+	err := client.SubscribeStockAggregates(conn, []string{"AAPL", "ZZZZ"}, StockEventTypeAM)
+
+	var statusErr StatusError
+	if assert.ErrorAs(t, err, &statusErr) {
+		assert.Equal(t, WebsocketStatusError, statusErr.Status)
+	}
+	assert.ErrorIs(t, err, ErrUnknownChannel)
+	if assert.Len(t, acks, 2) {
+		assert.Equal(t, SubscriptionAck{Action: "subscribe", Channel: "AM.AAPL"}, acks[0])
+		assert.ErrorIs(t, acks[1].Err, ErrUnknownChannel)
+	}
+}
+
+func TestSubscribeAcknowledgementTimeout(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = func(f *fakeStreamConn, msg string) {
+		if msg == `{"action":"auth","params":"test-token"}` {
+			polygonReplies(f, msg)
+		}
+	}
+
+	client := NewClient("test-token", WithWebsocketStatusTimeout(10*time.Millisecond))
+	err := client.SubscribeStockTrades(conn, []string{"AAPL"})
+	assert.ErrorIs(t, err, ErrStatusTimeout)
+}
+
+func TestSubscribeStatusTimeout(t *testing.T) {
+	conn := newFakeStreamConn()
+	client := NewClient("test-token", WithWebsocketStatusTimeout(10*time.Millisecond))
+
+	err := client.SubscribeForexAggregates(conn, []string{"EUR/USD"}, ForexEventTypeCA)
+	assert.ErrorIs(t, err, ErrStatusTimeout)
+
+	// the pending read is released by closing the connection
+	conn.mu.Lock()
+	assert.False(t, conn.isOpen)
+	conn.mu.Unlock()
+}
+
+// deadlineConn a fakeStreamConn with read deadlines
+type deadlineConn struct {
+	*fakeStreamConn
+	deadline time.Time
+}
+
+func (d *deadlineConn) SetReadDeadline(t time.Time) error {
+	d.deadline = t
+	return nil
+}
+
+func (d *deadlineConn) ReadMessage() (int, []byte, error) {
+	d.mu.Lock()
+	frames := d.frames
+	d.mu.Unlock()
+
+	select {
+	case data := <-frames:
+		return TextMessage, data, nil
+	case <-time.After(time.Until(d.deadline)):
+		return 0, nil, errors.New("i/o timeout")
+	}
+}
+
+func TestAwaitAuthReadDeadline(t *testing.T) {
+	conn := &deadlineConn{fakeStreamConn: newFakeStreamConn()}
+	conn.Dial("", nil)
+	client := NewClient("test-token", WithWebsocketStatusTimeout(10*time.Millisecond))
+
+	assert.ErrorIs(t, client.awaitAuth(conn), ErrStatusTimeout)
+	assert.True(t, conn.deadline.IsZero(), "deadline must be cleared")
+
+	// reads happen inline, so the connection is kept and no frame is lost
+	conn.push(`[{"ev":"status","status":"auth_success","message":"authenticated"}]`)
+	assert.NoError(t, client.awaitAuth(conn))
+}
+
+func TestStreamStopsOnAuthFailed(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = func(f *fakeStreamConn, msg string) {
+		f.push(`[{"ev":"status","status":"auth_failed","message":"authentication failed"}]`)
+	}
+
+	stream := NewClient("bad-token").NewStream(conn, ClusterStocks, nil, WithReconnectBackoff(time.Millisecond, time.Millisecond))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.ErrorIs(t, stream.Run(ctx), ErrAuthFailed)
+	assert.Equal(t, 1, conn.dialCount())
+}
+
+func TestStreamSubscriptionRejected(t *testing.T) {
+	conn := newFakeStreamConn()
+	// the server rejects the subscription even though the feed allows the channel
+	conn.onWrite = func(f *fakeStreamConn, msg string) {
+		polygonReplies(f, msg)
+		if msg != `{"action":"auth","params":"test-token"}` {
+			f.push(`[{"ev":"status","status":"error","message":"unknown channel: ZZ.AAPL"}]`)
+		}
+	}
+
+	acks := make(chan SubscriptionAck, 10)
+	stream := NewClient("test-token").NewStream(conn, ClusterStocks, []string{"AM.AAPL"},
+		WithSubscriptionHandler(func(ack SubscriptionAck) { acks <- ack }))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go stream.Run(ctx)
+
+	var rejected SubscriptionAck
+	for ack := range acks {
+		if ack.Err != nil {
+			rejected = ack
+			break
+		}
+	}
+	assert.ErrorIs(t, rejected.Err, ErrUnknownChannel)
+}
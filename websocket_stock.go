@@ -3,7 +3,7 @@ package polygon
 import (
 	"fmt"
 	"net/http"
-	"strings"
+	"time"
 )
 
 //////////////////////////////////////////////////////////////////////////////////////
@@ -53,9 +53,18 @@ const (
 	StockEventTypeAM StockEventTypeEnum = "AM"
 	// StockEventTypeA second aggregates
 	StockEventTypeA StockEventTypeEnum = "A"
+	// StockEventTypeT trades
+	StockEventTypeT StockEventTypeEnum = "T"
+	// StockEventTypeQ NBBO quotes
+	StockEventTypeQ StockEventTypeEnum = "Q"
+	// StockEventTypeLULD limit up - limit down price bands
+	StockEventTypeLULD StockEventTypeEnum = "LULD"
+	// StockEventTypeFMV fair market value
+	StockEventTypeFMV StockEventTypeEnum = "FMV"
 )
 
 type StockAggregate struct {
+	FeedTag
 	Event             StockEventTypeEnum `json:"ev"`
 	Symbol            string             `json:"sym"`
 	TickVolume        float64            `json:"v"`
@@ -73,34 +82,136 @@ type StockAggregate struct {
 	OTC               *bool              `json:"otc"`
 }
 
-func (c Client) SubscribeStockAggregates(client WebSocketClient, symbols []string, eventType StockEventTypeEnum) (err error) {
-	// connect
-	client.Dial(fmt.Sprintf("%s/stocks", c.websocketBaseURL), nil)
-	// auth
-	err = client.WriteMessage(TextMessage, []byte(fmt.Sprintf("{\"action\":\"auth\",\"params\":\"%s\"}", c.token)))
-	if err != nil {
-		return
-	}
+// EventType implements Event
+func (a StockAggregate) EventType() string { return string(a.Event) }
+
+// EventSymbol implements Event
+func (a StockAggregate) EventSymbol() string { return a.Symbol }
+
+// StockTrade tick-level trade
+type StockTrade struct {
+	FeedTag
+	Event                StockEventTypeEnum `json:"ev"`   // The event type.
+	Symbol               string             `json:"sym"`  // The ticker symbol for the given stock.
+	Exchange             int                `json:"x"`    // The exchange ID.
+	ID                   string             `json:"i"`    // The trade ID.
+	Tape                 int                `json:"z"`    // The tape (1 = NYSE, 2 = AMEX, 3 = Nasdaq).
+	Price                float64            `json:"p"`    // The price.
+	Size                 int64              `json:"s"`    // The trade size.
+	DecimalSize          string             `json:"ds"`   // The trade size including fractional shares.
+	Conditions           []int              `json:"c"`    // The trade conditions.
+	Timestamp            int64              `json:"t"`    // The SIP timestamp in Unix Milliseconds.
+	ParticipantTimestamp int64              `json:"y"`    // The participant (exchange) timestamp in Unix Milliseconds.
+	SequenceNumber       int64              `json:"q"`    // The sequence number, unique per ticker symbol and increasing.
+	TRFID                int                `json:"trfi"` // The ID of the Trade Reporting Facility, for off-exchange trades.
+	TRFTimestamp         int64              `json:"trft"` // The TRF timestamp in Unix Milliseconds.
+}
 
-	// subscribe
-	// https://polygon.io/docs/stocks/ws_stocks_am
-	channel := resolveStockChannel(symbols, eventType)
-	err = client.WriteMessage(TextMessage, []byte(fmt.Sprintf("{\"action\":\"subscribe\",\"params\":\"%s\"}", channel)))
-	if err != nil {
-		return
-	}
+// EventType implements Event
+func (t StockTrade) EventType() string { return string(t.Event) }
+
+// EventSymbol implements Event
+func (t StockTrade) EventSymbol() string { return t.Symbol }
+
+// Time returns the SIP timestamp as time
+func (t StockTrade) Time() time.Time { return time.UnixMilli(t.Timestamp) }
+
+// StockQuote NBBO quote
+type StockQuote struct {
+	FeedTag
+	Event                StockEventTypeEnum `json:"ev"`  // The event type.
+	Symbol               string             `json:"sym"` // The ticker symbol for the given stock.
+	BidExchange          int                `json:"bx"`  // The bid exchange ID.
+	BidPrice             float64            `json:"bp"`  // The bid price.
+	BidSize              int64              `json:"bs"`  // The bid size in round lots.
+	AskExchange          int                `json:"ax"`  // The ask exchange ID.
+	AskPrice             float64            `json:"ap"`  // The ask price.
+	AskSize              int64              `json:"as"`  // The ask size in round lots.
+	Condition            int                `json:"c"`   // The condition.
+	Indicators           []int              `json:"i"`   // The indicators.
+	Timestamp            int64              `json:"t"`   // The SIP timestamp in Unix Milliseconds.
+	ParticipantTimestamp int64              `json:"y"`   // The participant (exchange) timestamp in Unix Milliseconds.
+	SequenceNumber       int64              `json:"q"`   // The sequence number, unique per ticker symbol and increasing.
+	Tape                 int                `json:"z"`   // The tape (1 = NYSE, 2 = AMEX, 3 = Nasdaq).
+}
 
-	return
+// EventType implements Event
+func (q StockQuote) EventType() string { return string(q.Event) }
+
+// EventSymbol implements Event
+func (q StockQuote) EventSymbol() string { return q.Symbol }
+
+// Time returns the SIP timestamp as time
+func (q StockQuote) Time() time.Time { return time.UnixMilli(q.Timestamp) }
+
+// LimitUpLimitDown limit up - limit down price band of a symbol
+type LimitUpLimitDown struct {
+	FeedTag
+	Event          StockEventTypeEnum `json:"ev"` // The event type.
+	Symbol         string             `json:"T"`  // The ticker symbol for the given stock.
+	HighLimitPrice float64            `json:"h"`  // The high price limit for this event.
+	LowLimitPrice  float64            `json:"l"`  // The low price limit for this event.
+	Indicators     []int              `json:"i"`  // The indicators.
+	Tape           int                `json:"z"`  // The tape (1 = NYSE, 2 = AMEX, 3 = Nasdaq).
+	Timestamp      int64              `json:"t"`  // The timestamp in Unix Milliseconds.
+	SequenceNumber int64              `json:"q"`  // The sequence number, unique per ticker symbol and increasing.
 }
 
-func resolveStockChannel(symbols []string, eventType StockEventTypeEnum) string {
-	var sb strings.Builder
-	for i, symbol := range symbols {
-		sb.WriteString(fmt.Sprintf("%s.%s", eventType, symbol))
-		if i < len(symbols)-1 {
-			sb.WriteString(",")
-		}
-	}
+// EventType implements Event
+func (l LimitUpLimitDown) EventType() string { return string(l.Event) }
+
+// EventSymbol implements Event
+func (l LimitUpLimitDown) EventSymbol() string { return l.Symbol }
+
+// FairMarketValue polygon proprietary fair market value of a symbol
+type FairMarketValue struct {
+	FeedTag
+	Event     StockEventTypeEnum `json:"ev"`  // The event type.
+	Symbol    string             `json:"sym"` // The ticker symbol for the given stock.
+	FMV       float64            `json:"fmv"` // The fair market value.
+	Timestamp int64              `json:"t"`   // The timestamp in Unix Nanoseconds, as documented by polygon.
+}
+
+// EventType implements Event
+func (f FairMarketValue) EventType() string { return string(f.Event) }
+
+// EventSymbol implements Event
+func (f FairMarketValue) EventSymbol() string { return f.Symbol }
+
+// Time returns the timestamp as time
+func (f FairMarketValue) Time() time.Time { return time.Unix(0, f.Timestamp) }
 
-	return sb.String()
+// SubscribeStockTrades subscribes to the trades (T) of the given symbols
+func (c Client) SubscribeStockTrades(client WebSocketClient, symbols []string) error {
+	return c.SubscribeStockAggregates(client, symbols, StockEventTypeT)
+}
+
+// SubscribeStockQuotes subscribes to the NBBO quotes (Q) of the given symbols
+func (c Client) SubscribeStockQuotes(client WebSocketClient, symbols []string) error {
+	return c.SubscribeStockAggregates(client, symbols, StockEventTypeQ)
+}
+
+// SubscribeStockLULD subscribes to the limit up - limit down bands (LULD) of the given symbols
+func (c Client) SubscribeStockLULD(client WebSocketClient, symbols []string) error {
+	return c.SubscribeStockAggregates(client, symbols, StockEventTypeLULD)
+}
+
+// SubscribeStockFMV subscribes to the fair market value (FMV) of the given symbols
+func (c Client) SubscribeStockFMV(client WebSocketClient, symbols []string) error {
+	return c.SubscribeStockAggregates(client, symbols, StockEventTypeFMV)
+}
+
+// SubscribeStockAggregates subscribes to the given event type of the given symbols on the stocks cluster
+func (c Client) SubscribeStockAggregates(client WebSocketClient, symbols []string, eventType StockEventTypeEnum) error {
+	// https://polygon.io/docs/stocks/ws_stocks_am
+	return c.subscribe(client, ClusterStocks, StockChannels(eventType, symbols...))
+}
+
+// StockChannels returns the channels of the given event type for every symbol, e.g. "AM.AAPL"
+func StockChannels(eventType StockEventTypeEnum, symbols ...string) []string {
+	channels := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		channels = append(channels, fmt.Sprintf("%s.%s", eventType, symbol))
+	}
+	return channels
 }
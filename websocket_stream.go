@@ -0,0 +1,458 @@
+package polygon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cluster polygon websocket cluster
+type Cluster string
+
+const (
+	ClusterStocks  Cluster = "stocks"
+	ClusterCrypto  Cluster = "crypto"
+	ClusterForex   Cluster = "forex"
+	ClusterOptions Cluster = "options"
+	ClusterIndices Cluster = "indices"
+)
+
+// StreamConn is a WebSocketClient that can also read messages and be closed, as required by Stream.
+// Dial is called again on the same value after Close when the stream reconnects.
+type StreamConn interface {
+	WebSocketClient
+	ReadMessage() (messageType int, data []byte, err error)
+	Close() error
+}
+
+// StreamState connection state of a Stream
+type StreamState int
+
+const (
+	StreamStateDisconnected StreamState = iota
+	StreamStateConnecting
+	StreamStateConnected
+	StreamStateReconnecting
+	StreamStateClosed
+)
+
+// String implements fmt.Stringer
+func (s StreamState) String() string {
+	switch s {
+	case StreamStateDisconnected:
+		return "disconnected"
+	case StreamStateConnecting:
+		return "connecting"
+	case StreamStateConnected:
+		return "connected"
+	case StreamStateReconnecting:
+		return "reconnecting"
+	case StreamStateClosed:
+		return "closed"
+	}
+	return fmt.Sprintf("StreamState(%d)", int(s))
+}
+
+var (
+	// ErrStreamRunning Run was called on a stream that is already running
+	ErrStreamRunning = errors.New("stream: already running")
+	// ErrInvalidChannel a channel is not of the form "<event type>.<symbol>"
+	ErrInvalidChannel = errors.New("stream: invalid channel")
+)
+
+// Stream owns a websocket connection to a polygon cluster: it authenticates, subscribes,
+// reads messages and reconnects with backoff, replaying authentication and subscriptions.
+type Stream struct {
+	client  Client
+	conn    StreamConn
+	cluster Cluster
+
+	backoff       RetryPolicy
+	maxReconnects int
+	onStateChange func(StreamState, error)
+	onMessage     func([]byte)
+	onAck         func(SubscriptionAck)
+	acks          map[string]SubscriptionAck
+	running       bool
+	state         StreamState
+	subscriptions map[string]struct{}
+	live          bool  // the current session has subscribed, new subscriptions must be sent right away
+	err           error // invalid channels given to NewStream
+	mu            sync.Mutex
+	writeMu       sync.Mutex
+}
+
+// StreamOption applies an option to a Stream.
+type StreamOption func(*Stream)
+
+// WithReconnectBackoff sets the exponential backoff between reconnections (default 1s up to 30s)
+func WithReconnectBackoff(base, max time.Duration) StreamOption {
+	return func(s *Stream) {
+		s.backoff.BaseBackoff = base
+		s.backoff.MaxBackoff = max
+	}
+}
+
+// WithMaxReconnects stops the stream after n consecutive failed reconnections. Zero or less means no limit.
+func WithMaxReconnects(n int) StreamOption {
+	return func(s *Stream) {
+		s.maxReconnects = n
+	}
+}
+
+// WithStateHandler is called on every connection state change, err is the cause of the change if any
+func WithStateHandler(fn func(state StreamState, err error)) StreamOption {
+	return func(s *Stream) {
+		s.onStateChange = fn
+	}
+}
+
+// WithMessageHandler is called with every raw frame read from the connection
+func WithMessageHandler(fn func(data []byte)) StreamOption {
+	return func(s *Stream) {
+		s.onMessage = fn
+	}
+}
+
+// WithSubscriptionHandler is called with every subscription acknowledgement
+func WithSubscriptionHandler(fn func(SubscriptionAck)) StreamOption {
+	return func(s *Stream) {
+		s.onAck = fn
+	}
+}
+
+// NewStream creates a managed stream on the given cluster, subscribed to channels such as "AM.AAPL".
+// Nothing happens until Run is called.
+func (c Client) NewStream(conn StreamConn, cluster Cluster, channels []string, opts ...StreamOption) *Stream {
+	s := &Stream{
+		client:  c,
+		conn:    conn,
+		cluster: cluster,
+		backoff: RetryPolicy{
+			BaseBackoff: time.Second,
+			MaxBackoff:  30 * time.Second,
+			Jitter:      0.2,
+		},
+		subscriptions: make(map[string]struct{}),
+		acks:          make(map[string]SubscriptionAck),
+	}
+	// invalid channels are reported by Run
+	if s.err = validateChannels(channels); s.err == nil {
+		s.track(channels)
+	}
+
+	for _, applyOption := range opts {
+		applyOption(s)
+	}
+
+	return s
+}
+
+// State returns the current connection state
+func (s *Stream) State() StreamState {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state
+}
+
+// Run connects and reads messages until ctx is cancelled, reconnecting whenever the connection drops.
+// It returns nil once ctx is cancelled or a Replayer has replayed every frame,
+// or the last error when the reconnection limit is reached.
+// Subscriptions not available on the feed of the client are rejected with ErrFeedNotSupported.
+func (s *Stream) Run(ctx context.Context) error {
+	s.mu.Lock()
+	if s.running {
+		s.mu.Unlock()
+		return ErrStreamRunning
+	}
+	if s.err != nil {
+		s.mu.Unlock()
+		return s.err
+	}
+	if err := CheckFeed(s.client.feed, s.cluster, s.sortedSubscriptions()...); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	s.running = true
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.running = false
+		s.mu.Unlock()
+	}()
+
+	failures := 0
+	for {
+		healthy, err := s.session(ctx)
+		if ctx.Err() != nil {
+			s.setState(StreamStateClosed, nil)
+			return nil
+		}
+
+		// a rejected key will not be accepted on the next attempt either
+		if errors.Is(err, ErrAuthFailed) {
+			s.setState(StreamStateClosed, err)
+			return err
+		}
+
+		// a replayed recording has no more frames to deliver
+		if errors.Is(err, ErrReplayFinished) {
+			s.setState(StreamStateClosed, nil)
+			return nil
+		}
+
+		// neither has a corrupt one
+		if errors.Is(err, ErrReplayFailed) {
+			s.setState(StreamStateClosed, err)
+			return err
+		}
+
+		if healthy {
+			failures = 0
+		}
+		failures++
+		if s.maxReconnects > 0 && failures > s.maxReconnects {
+			s.setState(StreamStateClosed, err)
+			return err
+		}
+
+		s.setState(StreamStateReconnecting, err)
+		if sleepContext(ctx, s.backoff.backoff(failures)) != nil {
+			s.setState(StreamStateClosed, nil)
+			return nil
+		}
+	}
+}
+
+// session dials, authenticates, subscribes and reads until the connection fails.
+// healthy reports whether at least one message was read, which resets the reconnection count.
+func (s *Stream) session(ctx context.Context) (healthy bool, err error) {
+	s.setState(StreamStateConnecting, nil)
+	s.conn.Dial(fmt.Sprintf("%s/%s", s.client.websocketBaseURL, s.cluster), nil)
+	defer s.conn.Close()
+
+	// unblock ReadMessage on shutdown
+	stop := context.AfterFunc(ctx, func() { s.conn.Close() })
+	defer stop()
+
+	auth, err := s.client.protocolEncoder().Auth(s.client.token)
+	if err != nil {
+		return false, err
+	}
+	if err := s.write(auth); err != nil {
+		return false, err
+	}
+
+	if err := s.client.awaitAuth(s.conn); err != nil {
+		return false, err
+	}
+
+	// from now on Subscribe and Unsubscribe write to this connection
+	s.mu.Lock()
+	channels := s.activeSubscriptions()
+	s.live = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.live = false
+		s.mu.Unlock()
+	}()
+
+	if err := s.writeChannels(ActionSubscribe, channels); err != nil {
+		return false, err
+	}
+
+	s.setState(StreamStateConnected, nil)
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			return healthy, err
+		}
+		healthy = true
+
+		for _, m := range statusMessages(data) {
+			if ack, ok := m.subscriptionAck(); ok {
+				s.acknowledge(ack)
+			}
+		}
+
+		if s.onMessage != nil {
+			s.onMessage(data)
+		}
+	}
+}
+
+// Subscriptions returns the channels of the stream, including the ones covered by a wildcard
+func (s *Stream) Subscriptions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sortedSubscriptions()
+}
+
+// Subscribe adds channels such as "AM.AAPL" or "AM.*" to the stream, channels already subscribed are ignored.
+// Channels covered by a wildcard of the same event type are kept, and only sent once the wildcard is unsubscribed.
+// Subscribing to a wildcard keeps the channels of its event type, unsubscribe them to stop them on the server.
+// The subscribe action is sent right away when connected, otherwise on the next connection.
+func (s *Stream) Subscribe(channels ...string) error {
+	if err := validateChannels(channels); err != nil {
+		return err
+	}
+	if err := CheckFeed(s.client.feed, s.cluster, channels...); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	added := s.track(channels)
+	live := s.live
+	s.mu.Unlock()
+
+	if !live || len(added) == 0 {
+		return nil
+	}
+	return s.writeChannels(ActionSubscribe, added)
+}
+
+// Unsubscribe removes channels from the stream, channels not subscribed are ignored.
+// The unsubscribe action is sent right away when connected, followed by the subscribe action
+// of the channels an unsubscribed wildcard was covering.
+func (s *Stream) Unsubscribe(channels ...string) error {
+	if err := validateChannels(channels); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	var removed []string
+	for _, channel := range channels {
+		if _, ok := s.subscriptions[channel]; ok {
+			delete(s.subscriptions, channel)
+			removed = append(removed, channel)
+		}
+	}
+	var uncovered []string
+	for _, channel := range s.sortedSubscriptions() {
+		eventType, _, _ := strings.Cut(channel, ".")
+		if slices.Contains(removed, eventType+".*") && !s.covered(channel) {
+			uncovered = append(uncovered, channel)
+		}
+	}
+	live := s.live
+	s.mu.Unlock()
+
+	if !live || len(removed) == 0 {
+		return nil
+	}
+	if err := s.writeChannels(ActionUnsubscribe, removed); err != nil {
+		return err
+	}
+	if len(uncovered) == 0 {
+		return nil
+	}
+	return s.writeChannels(ActionSubscribe, uncovered)
+}
+
+// track adds channels to the subscription set and returns the ones to send, that is the added channels
+// not covered by a wildcard. It must be called with mu held or before Run.
+func (s *Stream) track(channels []string) []string {
+	var added []string
+	for _, channel := range channels {
+		if _, ok := s.subscriptions[channel]; ok {
+			continue
+		}
+		s.subscriptions[channel] = struct{}{}
+		added = append(added, channel)
+	}
+	return slices.DeleteFunc(added, s.covered)
+}
+
+// covered reports whether a channel is covered by a subscribed wildcard of its event type,
+// it must be called with mu held or before Run
+func (s *Stream) covered(channel string) bool {
+	eventType, symbol, _ := strings.Cut(channel, ".")
+	if symbol == "*" {
+		return false
+	}
+	_, ok := s.subscriptions[eventType+".*"]
+	return ok
+}
+
+func (s *Stream) sortedSubscriptions() []string {
+	return slices.Sorted(maps.Keys(s.subscriptions))
+}
+
+// activeSubscriptions returns the channels to send on a connection, it must be called with mu held
+func (s *Stream) activeSubscriptions() []string {
+	return slices.DeleteFunc(s.sortedSubscriptions(), s.covered)
+}
+
+// validateChannels checks channels are of the form "<event type>.<symbol>"
+func validateChannels(channels []string) error {
+	for _, channel := range channels {
+		eventType, symbol, ok := strings.Cut(channel, ".")
+		if !ok || eventType == "" || symbol == "" || strings.Contains(channel, ",") {
+			return fmt.Errorf("%w: %q", ErrInvalidChannel, channel)
+		}
+	}
+	return nil
+}
+
+// Acknowledgement returns the last acknowledgement received for a channel
+func (s *Stream) Acknowledgement(channel string) (SubscriptionAck, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ack, ok := s.acks[channel]
+	return ack, ok
+}
+
+func (s *Stream) acknowledge(ack SubscriptionAck) {
+	s.mu.Lock()
+	s.acks[ack.Channel] = ack
+	s.mu.Unlock()
+
+	if s.onAck != nil {
+		s.onAck(ack)
+	}
+}
+
+// writeChannels sends a subscribe or unsubscribe action, split into several frames if needed
+func (s *Stream) writeChannels(action string, channels []string) error {
+	encoder := s.client.protocolEncoder()
+	encode := encoder.Subscribe
+	if action == ActionUnsubscribe {
+		encode = encoder.Unsubscribe
+	}
+
+	frames, err := encode(channels)
+	if err != nil {
+		return err
+	}
+	return s.write(frames...)
+}
+
+func (s *Stream) write(frames ...[]byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	for _, frame := range frames {
+		if err := s.conn.WriteMessage(TextMessage, frame); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Stream) setState(state StreamState, err error) {
+	s.mu.Lock()
+	changed := s.state != state
+	s.state = state
+	s.mu.Unlock()
+
+	if (changed || err != nil) && s.onStateChange != nil {
+		s.onStateChange(state, err)
+	}
+}
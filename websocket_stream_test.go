@@ -0,0 +1,256 @@
+package polygon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStreamConn in-memory StreamConn, every Dial opens a new fake connection
+type fakeStreamConn struct {
+	mu      sync.Mutex
+	dials   []string
+	writes  []string
+	frames  chan []byte
+	closed  chan struct{}
+	isOpen  bool
+	onWrite func(f *fakeStreamConn, msg string)
+}
+
+func newFakeStreamConn() *fakeStreamConn {
+	return &fakeStreamConn{closed: make(chan struct{})}
+}
+
+func (f *fakeStreamConn) Dial(urlStr string, reqHeader http.Header) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.dials = append(f.dials, urlStr)
+	f.frames = make(chan []byte, 100)
+	f.closed = make(chan struct{})
+	f.isOpen = true
+}
+
+func (f *fakeStreamConn) WriteMessage(messageType int, data []byte) error {
+	f.mu.Lock()
+	if !f.isOpen {
+		f.mu.Unlock()
+		return io.ErrClosedPipe
+	}
+	f.writes = append(f.writes, string(data))
+	onWrite := f.onWrite
+	f.mu.Unlock()
+
+	if onWrite != nil {
+		onWrite(f, string(data))
+	}
+	return nil
+}
+
+func (f *fakeStreamConn) ReadMessage() (int, []byte, error) {
+	f.mu.Lock()
+	frames, closed := f.frames, f.closed
+	f.mu.Unlock()
+
+	select {
+	case data := <-frames:
+		return TextMessage, data, nil
+	case <-closed:
+		return 0, nil, io.EOF
+	}
+}
+
+func (f *fakeStreamConn) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.isOpen {
+		f.isOpen = false
+		close(f.closed)
+	}
+	return nil
+}
+
+// push queues a frame on the current connection
+func (f *fakeStreamConn) push(frame string) {
+	f.mu.Lock()
+	frames := f.frames
+	f.mu.Unlock()
+	frames <- []byte(frame)
+}
+
+// polygonReplies answers auth and subscribe actions like polygon does
+func polygonReplies(f *fakeStreamConn, msg string) {
+	var action struct {
+		Action string `json:"action"`
+		Params string `json:"params"`
+	}
+	json.Unmarshal([]byte(msg), &action)
+
+	switch action.Action {
+	case "auth":
+		f.push(`[{"ev":"status","status":"auth_success","message":"authenticated"}]`)
+	case "subscribe", "unsubscribe":
+		for _, channel := range strings.Split(action.Params, ",") {
+			f.push(fmt.Sprintf(`[{"ev":"status","status":"success","message":"%sd to: %s"}]`, action.Action, channel))
+		}
+	}
+}
+
+func (f *fakeStreamConn) dialCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.dials)
+}
+
+// dialed returns the URL of the last Dial
+func (f *fakeStreamConn) dialed() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.dials) == 0 {
+		return ""
+	}
+	return f.dials[len(f.dials)-1]
+}
+
+func (f *fakeStreamConn) written() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.writes...)
+}
+
+func TestStreamReconnectReplaysAuthAndSubscriptions(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+	var mu sync.Mutex
+	var messages []string
+	var states []StreamState
+
+	client := NewClient("test-token", WithWebsocketBaseURL("wss://test"))
+	stream := client.NewStream(conn, ClusterStocks, StockChannels(StockEventTypeAM, "AAPL", "NVDA"),
+		WithReconnectBackoff(time.Millisecond, time.Millisecond),
+		WithMessageHandler(func(data []byte) {
+			if !strings.Contains(string(data), "status") {
+				mu.Lock()
+				messages = append(messages, string(data))
+				mu.Unlock()
+			}
+		}),
+		WithStateHandler(func(state StreamState, err error) {
+			mu.Lock()
+			states = append(states, state)
+			mu.Unlock()
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- stream.Run(ctx) }()
+
+	assert.Eventually(t, func() bool { return stream.State() == StreamStateConnected }, time.Second, time.Millisecond)
+	conn.push(`[{"ev":"AM","sym":"AAPL"}]`)
+
+	// drop the connection, the stream reconnects and subscribes again
+	conn.Close()
+	assert.Eventually(t, func() bool { return conn.dialCount() == 2 && stream.State() == StreamStateConnected }, time.Second, time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Equal(t, StreamStateClosed, stream.State())
+
+	auth := `{"action":"auth","params":"test-token"}`
+	subscribe := `{"action":"subscribe","params":"AM.AAPL,AM.NVDA"}`
+	assert.Equal(t, []string{auth, subscribe, auth, subscribe}, conn.written())
+	assert.Equal(t, []string{"wss://test/stocks", "wss://test/stocks"}, conn.dials)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{`[{"ev":"AM","sym":"AAPL"}]`}, messages)
+	assert.Contains(t, states, StreamStateReconnecting)
+
+	ack, ok := stream.Acknowledgement("AM.NVDA")
+	assert.True(t, ok)
+	assert.Equal(t, SubscriptionAck{Action: "subscribe", Channel: "AM.NVDA"}, ack)
+}
+
+func TestStreamMaxReconnects(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = func(f *fakeStreamConn, msg string) {
+		go f.Close()
+	}
+
+	stream := NewClient("test-token").NewStream(conn, ClusterCrypto, CryptoChannels(CryptoEventTypeXA, "BTC-USD"),
+		WithReconnectBackoff(time.Millisecond, time.Millisecond),
+		WithMaxReconnects(2),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.ErrorIs(t, stream.Run(ctx), io.EOF)
+}
+
+func TestStreamDynamicSubscriptions(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+
+	stream := NewClient("test-token").NewStream(conn, ClusterStocks, []string{"AM.AAPL"},
+		WithReconnectBackoff(time.Millisecond, time.Millisecond))
+
+	// before running, subscriptions are only tracked
+	assert.NoError(t, stream.Subscribe("AM.MSFT", "AM.AAPL"))
+	assert.ErrorIs(t, stream.Subscribe("AAPL"), ErrInvalidChannel)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go stream.Run(ctx)
+	assert.Eventually(t, func() bool { return stream.State() == StreamStateConnected }, time.Second, time.Millisecond)
+
+	assert.NoError(t, stream.Subscribe("A.NVDA", "AM.AAPL"))
+	assert.NoError(t, stream.Unsubscribe("AM.MSFT", "AM.TSLA"))
+	assert.NoError(t, stream.Subscribe("AM.*"))
+	assert.NoError(t, stream.Subscribe("AM.GOOG"))
+	// AM.AAPL is still subscribed on the server, AM.GOOG is kept for when the wildcard goes
+	assert.Equal(t, []string{"A.NVDA", "AM.*", "AM.AAPL", "AM.GOOG"}, stream.Subscriptions())
+
+	// a reconnect restores the current set, channels covered by the wildcard are not sent
+	conn.Close()
+	assert.Eventually(t, func() bool { return conn.dialCount() == 2 && stream.State() == StreamStateConnected }, time.Second, time.Millisecond)
+
+	auth := `{"action":"auth","params":"test-token"}`
+	assert.Equal(t, []string{
+		auth,
+		`{"action":"subscribe","params":"AM.AAPL,AM.MSFT"}`,
+		`{"action":"subscribe","params":"A.NVDA"}`,
+		`{"action":"unsubscribe","params":"AM.MSFT"}`,
+		`{"action":"subscribe","params":"AM.*"}`,
+		auth,
+		`{"action":"subscribe","params":"A.NVDA,AM.*"}`,
+	}, conn.written())
+
+	// dropping the wildcard sends the channels it was covering
+	assert.NoError(t, stream.Unsubscribe("AM.*"))
+	assert.Equal(t, []string{"A.NVDA", "AM.AAPL", "AM.GOOG"}, stream.Subscriptions())
+	assert.Equal(t, []string{
+		`{"action":"unsubscribe","params":"AM.*"}`,
+		`{"action":"subscribe","params":"AM.AAPL,AM.GOOG"}`,
+	}, conn.written()[7:])
+
+	// and they survive the next reconnect
+	conn.Close()
+	assert.Eventually(t, func() bool { return conn.dialCount() == 3 && stream.State() == StreamStateConnected }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{auth, `{"action":"subscribe","params":"A.NVDA,AM.AAPL,AM.GOOG"}`}, conn.written()[9:])
+}
+
+func TestNewStreamInvalidChannels(t *testing.T) {
+	conn := newFakeStreamConn()
+	stream := NewClient("test-token").NewStream(conn, ClusterStocks, []string{"AM.AAPL", "AAPL"})
+	assert.ErrorIs(t, stream.Run(context.Background()), ErrInvalidChannel)
+	assert.Empty(t, stream.Subscriptions())
+	assert.Equal(t, 0, conn.dialCount())
+}
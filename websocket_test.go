@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/websocket"
 )
 
@@ -77,3 +78,11 @@ func TestSubscribeAggregatesPerMinute(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+func TestSubscribeStockTrades(t *testing.T) {
+	conn := newFakeStreamConn()
+	conn.onWrite = polygonReplies
+
+	assert.NoError(t, NewClient("test-token").SubscribeStockTrades(conn, []string{"AAPL", "MSFT"}))
+	assert.Equal(t, `{"action":"subscribe","params":"T.AAPL,T.MSFT"}`, conn.written()[1])
+}